@@ -14,19 +14,38 @@
 package api
 
 import (
-	"log"
+	"fmt"
 	"os"
+	"reflect"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"gopkg.in/yaml.v3"
 )
 
-func Compile(yamlPath string, obj interface{}) {
-	objYaml, err := os.ReadFile(yamlPath)
+// Compile decodes the YAML file at yamlPath into obj. Decoding problems are
+// returned as *google.YamlError values rather than aborting, so callers can
+// report every broken file in a single run.
+func Compile(yamlPath string, obj interface{}) error {
+	return CompileSource(yamlPath, SourceFile{Path: yamlPath}, obj)
+}
 
+// CompileSource behaves like Compile, and records src as the file in the
+// SourcePos of every Product, Resource and Type decoded from yamlPath.
+func CompileSource(yamlPath string, src SourceFile, obj interface{}) error {
+	objYaml, err := os.ReadFile(yamlPath)
 	if err != nil {
-		log.Fatalf("Cannot open the file: %s", yamlPath)
+		return fmt.Errorf("cannot open the file %s: %w", yamlPath, err)
 	}
 
 	yamlValidator := google.YamlValidator{}
-	yamlValidator.Parse(objYaml, obj, yamlPath)
+	if err := yamlValidator.Parse(objYaml, obj, yamlPath); err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(objYaml, &node); err != nil {
+		return err
+	}
+	recordSourcePositions(&node, reflect.ValueOf(obj), src)
+	return nil
 }
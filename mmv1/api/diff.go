@@ -0,0 +1,254 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of FieldDiff.
+const (
+	DiffAdded     = "added"
+	DiffRemoved   = "removed"
+	DiffChanged   = "changed"
+	DiffReordered = "reordered"
+)
+
+// FieldDiff is a single difference between two resources. Path uses YAML
+// field names, with list elements that have a name addressed by it, e.g.
+// "properties[labels].description".
+type FieldDiff struct {
+	Path   string
+	Kind   string
+	Before string
+	After  string
+}
+
+func (d FieldDiff) String() string {
+	switch d.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("+ %s: %s", d.Path, d.After)
+	case DiffRemoved:
+		return fmt.Sprintf("- %s: %s", d.Path, d.Before)
+	case DiffReordered:
+		return fmt.Sprintf("~ %s reordered: %s -> %s", d.Path, d.Before, d.After)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", d.Path, d.Before, d.After)
+	}
+}
+
+// DiffResources compares two versions of a resource, typically as loaded
+// without and with overrides, field by field. Only fields that can be set
+// from YAML are compared. Lists of named elements, such as properties and
+// examples, are matched by name at every level of nesting.
+func DiffResources(before, after *Resource) []FieldDiff {
+	var diffs []FieldDiff
+	diffValues(&diffs, "", reflect.ValueOf(before).Elem(), reflect.ValueOf(after).Elem())
+	return diffs
+}
+
+func diffValues(diffs *[]FieldDiff, path string, before, after reflect.Value) {
+	beforeZero, afterZero := isZeroValue(before), isZeroValue(after)
+	switch {
+	case beforeZero && afterZero:
+		return
+	case beforeZero:
+		*diffs = append(*diffs, FieldDiff{Path: path, Kind: DiffAdded, After: formatValue(after)})
+		return
+	case afterZero:
+		*diffs = append(*diffs, FieldDiff{Path: path, Kind: DiffRemoved, Before: formatValue(before)})
+		return
+	}
+
+	for before.Kind() == reflect.Ptr || before.Kind() == reflect.Interface {
+		before, after = before.Elem(), after.Elem()
+	}
+	if before.Type() != after.Type() {
+		*diffs = append(*diffs, FieldDiff{Path: path, Kind: DiffChanged, Before: formatValue(before), After: formatValue(after)})
+		return
+	}
+
+	switch before.Kind() {
+	case reflect.Struct:
+		for _, f := range orderedYamlFields(before.Type()) {
+			diffValues(diffs, joinPath(path, f.name), before.FieldByIndex(f.index), after.FieldByIndex(f.index))
+		}
+	case reflect.Slice:
+		if isNamedList(before.Type()) {
+			diffNamedLists(diffs, path, before, after)
+			return
+		}
+		if !reflect.DeepEqual(before.Interface(), after.Interface()) {
+			*diffs = append(*diffs, FieldDiff{Path: path, Kind: DiffChanged, Before: formatValue(before), After: formatValue(after)})
+		}
+	case reflect.Map:
+		keys := make(map[string]reflect.Value)
+		for _, k := range append(before.MapKeys(), after.MapKeys()...) {
+			keys[fmt.Sprint(k.Interface())] = k
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			k := keys[name]
+			diffValues(diffs, fmt.Sprintf("%s[%s]", path, name), before.MapIndex(k), after.MapIndex(k))
+		}
+	default:
+		if !reflect.DeepEqual(before.Interface(), after.Interface()) {
+			*diffs = append(*diffs, FieldDiff{Path: path, Kind: DiffChanged, Before: formatValue(before), After: formatValue(after)})
+		}
+	}
+}
+
+// diffNamedLists matches the elements of two lists by name, and reports a
+// reordering if the elements present in both lists are in a different order.
+func diffNamedLists(diffs *[]FieldDiff, path string, before, after reflect.Value) {
+	beforeNames, afterNames := elementNames(before), elementNames(after)
+	afterIndex := make(map[string]int)
+	for i, name := range afterNames {
+		afterIndex[name] = i
+	}
+	beforeIndex := make(map[string]int)
+	for i, name := range beforeNames {
+		beforeIndex[name] = i
+	}
+
+	var commonBefore, commonAfter []string
+	for i, name := range beforeNames {
+		elementPath := fmt.Sprintf("%s[%s]", path, name)
+		if j, ok := afterIndex[name]; ok {
+			commonBefore = append(commonBefore, name)
+			diffValues(diffs, elementPath, before.Index(i), after.Index(j))
+		} else {
+			*diffs = append(*diffs, FieldDiff{Path: elementPath, Kind: DiffRemoved, Before: formatValue(before.Index(i))})
+		}
+	}
+	for j, name := range afterNames {
+		if _, ok := beforeIndex[name]; ok {
+			commonAfter = append(commonAfter, name)
+			continue
+		}
+		*diffs = append(*diffs, FieldDiff{Path: fmt.Sprintf("%s[%s]", path, name), Kind: DiffAdded, After: formatValue(after.Index(j))})
+	}
+	if !reflect.DeepEqual(commonBefore, commonAfter) {
+		*diffs = append(*diffs, FieldDiff{
+			Path:   path,
+			Kind:   DiffReordered,
+			Before: strings.Join(commonBefore, ", "),
+			After:  strings.Join(commonAfter, ", "),
+		})
+	}
+}
+
+// isNamedList reports whether t is a list of structs with a Name field.
+func isNamedList(t reflect.Type) bool {
+	elem := t.Elem()
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return false
+	}
+	f, ok := elem.FieldByName("Name")
+	return ok && f.Type.Kind() == reflect.String
+}
+
+func elementNames(list reflect.Value) []string {
+	names := make([]string, list.Len())
+	for i := range names {
+		names[i] = reflect.Indirect(list.Index(i)).FieldByName("Name").String()
+	}
+	return names
+}
+
+type yamlField struct {
+	name  string
+	index []int
+}
+
+// orderedYamlFields returns the YAML fields of t in declaration order.
+func orderedYamlFields(t reflect.Type) []yamlField {
+	var fields []yamlField
+	for name, index := range yamlFields(t) {
+		fields = append(fields, yamlField{name, index})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return fields
+}
+
+func isZeroValue(v reflect.Value) bool {
+	if !v.IsValid() || v.IsZero() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// formatValue renders a value as single-line YAML. Structs with a name are
+// summarized, as their fields are diffed separately when both sides exist.
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "null"
+	}
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	if s := reflect.Indirect(v); s.Kind() == reflect.Struct {
+		if f := s.FieldByName("Name"); f.IsValid() && f.Kind() == reflect.String {
+			return fmt.Sprintf("{name: %q, ...}", f.String())
+		}
+	}
+	var node yaml.Node
+	if err := node.Encode(v.Interface()); err != nil {
+		return fmt.Sprint(v.Interface())
+	}
+	setFlowStyle(&node)
+	b, err := yaml.Marshal(&node)
+	if err != nil {
+		return fmt.Sprint(v.Interface())
+	}
+	return strings.Join(strings.Fields(string(b)), " ")
+}
+
+func setFlowStyle(node *yaml.Node) {
+	node.Style |= yaml.FlowStyle
+	for _, n := range node.Content {
+		setFlowStyle(n)
+	}
+}
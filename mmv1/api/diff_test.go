@@ -0,0 +1,83 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffResources(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		before      Resource
+		after       Resource
+		expected    []string
+	}{
+		{
+			description: "identical resources",
+			before:      Resource{Name: "Topic", Properties: []*Type{{Name: "name", Type: "String"}}},
+			after:       Resource{Name: "Topic", Properties: []*Type{{Name: "name", Type: "String"}}},
+		},
+		{
+			description: "scalar fields",
+			before:      Resource{Name: "Topic", Description: "old", Immutable: true},
+			after:       Resource{Name: "Topic", Description: "new", MinVersion: "beta"},
+			expected: []string{
+				`~ description: "old" -> "new"`,
+				`+ min_version: "beta"`,
+				`- immutable: true`,
+			},
+		},
+		{
+			description: "properties matched by name",
+			before: Resource{Properties: []*Type{
+				{Name: "name", Type: "String"},
+				{Name: "labels", Type: "KeyValueLabels"},
+			}},
+			after: Resource{Properties: []*Type{
+				{Name: "labels", Type: "KeyValueLabels", Required: true},
+				{Name: "name", Type: "String"},
+				{Name: "kmsKeyName", Type: "String"},
+			}},
+			expected: []string{
+				`+ properties[labels].required: true`,
+				`+ properties[kmsKeyName]: {name: "kmsKeyName", ...}`,
+				`~ properties reordered: name, labels -> labels, name`,
+			},
+		},
+		{
+			description: "nested properties",
+			before: Resource{Properties: []*Type{
+				{Name: "config", Type: "NestedObject", Properties: []*Type{
+					{Name: "mode", Type: "Enum", EnumValues: []string{"A", "B"}},
+					{Name: "legacy", Type: "Boolean"},
+				}},
+			}},
+			after: Resource{Properties: []*Type{
+				{Name: "config", Type: "NestedObject", Properties: []*Type{
+					{Name: "mode", Type: "Enum", EnumValues: []string{"A", "B", "C"}},
+				}},
+			}},
+			expected: []string{
+				`~ properties[config].properties[mode].enum_values: [A, B] -> [A, B, C]`,
+				`- properties[config].properties[legacy]: {name: "legacy", ...}`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, d := range DiffResources(&tc.before, &tc.after) {
+				got = append(got, d.String())
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("DiffResources() =\n%q\nwant\n%q", got, tc.expected)
+			}
+		})
+	}
+}
@@ -26,6 +26,14 @@ func FromProperties(props []*api.Type) []Field {
 		f := Field{
 			Json:         p.IsJsonField(),
 			ProviderOnly: p.ProviderOnly(),
+			Type:         p.Type,
+			Required:     p.Required,
+			Output:       isOutput(p),
+			Immutable:    p.ResourceMetadata != nil && p.IsForceNew(),
+			Sensitive:    p.Sensitive,
+			WriteOnly:    p.WriteOnly || p.WriteOnlyLegacy,
+			MinVersion:   minVersion(p),
+			Deprecated:   p.Deprecated(),
 		}
 		lineage := p.Lineage()
 		apiLineage := p.ApiLineage()
@@ -34,6 +42,7 @@ func FromProperties(props []*api.Type) []Field {
 		if p.IsA("Map") {
 			lineage = append(lineage, p.KeyName)
 			apiLineage = append(apiLineage, "key")
+			f.Type = "String"
 		}
 		if !p.ProviderOnly() {
 			f.ApiField = strings.Join(apiLineage, ".")
@@ -47,6 +56,28 @@ func FromProperties(props []*api.Type) []Field {
 	return fields
 }
 
+// isOutput returns whether a field is output only, which nested fields
+// inherit from their parents.
+func isOutput(p *api.Type) bool {
+	for t := p; t != nil; t = t.Parent() {
+		if t.Output {
+			return true
+		}
+	}
+	return false
+}
+
+// minVersion returns the min_version of a field, which nested fields inherit
+// from their parents. Fields available at ga have none.
+func minVersion(p *api.Type) string {
+	for t := p; t != nil; t = t.Parent() {
+		if t.MinVersion != "" && t.MinVersion != "ga" {
+			return t.MinVersion
+		}
+	}
+	return ""
+}
+
 // Field is a field in a metadata.yaml file.
 type Field struct {
 	// The name of the field in the REST API, including the path. For example, "buildConfig.source.storageSource.bucket".
@@ -60,6 +91,22 @@ type Field struct {
 	// If true, this is a JSON field which "covers" all child API fields. As a special case, JSON fields which cover an entire resource can
 	// have `api_field` set to `*`.
 	Json bool `yaml:"json,omitempty"`
+	// The MMv1 type of the field, for example "String" or "NestedObject". Map keys are "String".
+	Type string `yaml:"type,omitempty"`
+	// If true, the field must be set in Terraform.
+	Required bool `yaml:"required,omitempty"`
+	// If true, the field is output only, either itself or through a parent field.
+	Output bool `yaml:"output,omitempty"`
+	// If true, changing the field recreates the resource.
+	Immutable bool `yaml:"immutable,omitempty"`
+	// If true, the field's value is hidden in plans and outputs.
+	Sensitive bool `yaml:"sensitive,omitempty"`
+	// If true, the field is write-only and never stored in state.
+	WriteOnly bool `yaml:"write_only,omitempty"`
+	// The first provider version the field is available in, if it isn't ga, for example "beta". Inherited from parent fields.
+	MinVersion string `yaml:"min_version,omitempty"`
+	// If true, the field is deprecated and will be removed in a future major release.
+	Deprecated bool `yaml:"deprecated,omitempty"`
 }
 
 // Returns true if the lineage is the default we'd expect for a field, and false otherwise.
@@ -94,6 +94,7 @@ func TestFromProperties(t *testing.T) {
 			wantFields: []Field{
 				{
 					ApiField: "root.foo.bars.fooBar",
+					Type:     "String",
 				},
 			},
 		},
@@ -115,6 +116,7 @@ func TestFromProperties(t *testing.T) {
 				{
 					Field:        "root.foo",
 					ProviderOnly: true,
+					Type:         "String",
 				},
 			},
 		},
@@ -137,6 +139,7 @@ func TestFromProperties(t *testing.T) {
 				{
 					Field:        "root.foo",
 					ProviderOnly: true,
+					Type:         "String",
 				},
 			},
 		},
@@ -162,10 +165,79 @@ func TestFromProperties(t *testing.T) {
 				{
 					Field:    "root.whatever",
 					ApiField: "root.key",
+					Type:     "String",
 				},
 				{
 					Field:    "root.foo",
 					ApiField: "root.value.foo",
+					Type:     "String",
+				},
+			},
+		},
+		{
+			name: "flags",
+			properties: []*api.Type{
+				{
+					Name:     "name",
+					Type:     "String",
+					Required: true,
+				},
+				{
+					Name:      "zone",
+					Type:      "String",
+					Immutable: true,
+				},
+				{
+					Name:      "password",
+					Type:      "String",
+					Sensitive: true,
+					WriteOnly: true,
+				},
+				{
+					Name:               "legacy",
+					Type:               "Integer",
+					DeprecationMessage: "`legacy` is deprecated.",
+				},
+				{
+					Name:       "status",
+					Type:       "NestedObject",
+					Output:     true,
+					MinVersion: "beta",
+					Properties: []*api.Type{
+						{
+							Name: "state",
+							Type: "Enum",
+						},
+					},
+				},
+			},
+			wantFields: []Field{
+				{
+					ApiField:   "legacy",
+					Type:       "Integer",
+					Deprecated: true,
+				},
+				{
+					ApiField: "name",
+					Type:     "String",
+					Required: true,
+				},
+				{
+					ApiField:  "password",
+					Type:      "String",
+					Sensitive: true,
+					WriteOnly: true,
+				},
+				{
+					ApiField:   "status.state",
+					Type:       "Enum",
+					Output:     true,
+					MinVersion: "beta",
+				},
+				{
+					ApiField:  "zone",
+					Type:      "String",
+					Immutable: true,
 				},
 			},
 		},
@@ -33,6 +33,8 @@ func FromResource(r api.Resource) Metadata {
 	if r.HasSelfLink {
 		m.Fields = append(m.Fields, Field{
 			ApiField: "selfLink",
+			Type:     "String",
+			Output:   true,
 		})
 	}
 	return m
@@ -86,6 +86,8 @@ func TestFromResource(t *testing.T) {
 					},
 					{
 						ApiField: "selfLink",
+						Type:     "String",
+						Output:   true,
 					},
 				},
 			},
@@ -22,6 +22,7 @@ import (
 	"unicode"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"golang.org/x/exp/slices"
 	"gopkg.in/yaml.v3"
@@ -80,6 +81,9 @@ type Product struct {
 
 	// ImportPath contains the prefix used for importing packages in generated files.
 	ImportPath string `yaml:"-"`
+
+	// Where the product was declared in the YAML input.
+	SourcePos SourcePosition `yaml:"-"`
 }
 
 func (p *Product) UnmarshalYAML(value *yaml.Node) error {
@@ -96,15 +100,15 @@ func (p *Product) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
-func (p *Product) Validate() {
+func (p *Product) Validate() (es []error) {
 	if len(p.Name) == 0 {
-		log.Fatalf("Missing `name` for product")
+		es = append(es, fmt.Errorf("missing `name` for product"))
 	}
 
 	// product names must start with a capital
 	for i, ch := range p.Name {
 		if !unicode.IsUpper(ch) {
-			log.Fatalf("product name `%s` must start with a capital letter.", p.Name)
+			es = append(es, fmt.Errorf("product name `%s` must start with a capital letter", p.Name))
 		}
 		if i == 0 {
 			break
@@ -112,16 +116,20 @@ func (p *Product) Validate() {
 	}
 
 	if len(p.Scopes) == 0 {
-		log.Fatalf("Missing `scopes` for product %s", p.Name)
+		es = append(es, fmt.Errorf("missing `scopes` for product %s", p.Name))
 	}
 
 	if p.Versions == nil {
-		log.Fatalf("Missing `versions` for product %s", p.Name)
+		es = append(es, fmt.Errorf("missing `versions` for product %s", p.Name))
 	}
 
 	for _, v := range p.Versions {
-		v.Validate(p.Name)
+		es = append(es, v.Validate(p.Name)...)
 	}
+
+	return utils.TransformErrs(func(e error) error {
+		return fmt.Errorf("%w%s", e, p.SourcePos.errorSuffix())
+	}, es)
 }
 
 // ====================
@@ -296,15 +304,8 @@ func (p Product) Lineage() string {
 func Merge(self, otherObj reflect.Value, version string) {
 	selfObj := reflect.Indirect(self)
 
-	// Skip merge if otherObj targets a higher version than what is being generated
-	for i := 0; i < otherObj.NumField(); i++ {
-		if otherObj.Type().Field(i).Name == "MinVersion" {
-			for j := slices.Index(product.ORDER, version) + 1; j < len(product.ORDER); j++ {
-				if otherObj.Field(i).String() == product.ORDER[j] {
-					return
-				}
-			}
-		}
+	if aboveVersion(otherObj, version) {
+		return
 	}
 
 	for i := 0; i < selfObj.NumField(); i++ {
@@ -332,6 +333,7 @@ func Merge(self, otherObj reflect.Value, version string) {
 func DeepMerge(arr1, arr2 reflect.Value, version string) {
 	if arr1.Len() == 0 {
 		arr1.Set(arr2)
+		applyOverrideAfter(arr1, arr2)
 		return
 	}
 	if arr2.Len() == 0 {
@@ -347,6 +349,7 @@ func DeepMerge(arr1, arr2 reflect.Value, version string) {
 	}
 
 	// Merge any elements that exist in both
+	removed := make(map[int]bool)
 	for i := 0; i < arr1.Len(); i++ {
 		currentVal := arr1.Index(i)
 		pointer := currentVal.Kind() == reflect.Ptr
@@ -367,9 +370,14 @@ func DeepMerge(arr1, arr2 reflect.Value, version string) {
 				break
 			}
 		}
-		if otherVal.IsValid() {
-			Merge(currentVal, otherVal, version)
+		if !otherVal.IsValid() {
+			continue
+		}
+		if overrideRemoves(otherVal, version) {
+			removed[i] = true
+			continue
 		}
+		Merge(currentVal, otherVal, version)
 	}
 
 	// Add any elements of arr2 that don't exist in arr1
@@ -398,4 +406,86 @@ func DeepMerge(arr1, arr2 reflect.Value, version string) {
 			arr1.Set(reflect.Append(arr1, arr2.Index(i)))
 		}
 	}
+
+	if len(removed) > 0 {
+		kept := reflect.MakeSlice(arr1.Type(), 0, arr1.Len()-len(removed))
+		for i := 0; i < arr1.Len(); i++ {
+			if !removed[i] {
+				kept = reflect.Append(kept, arr1.Index(i))
+			}
+		}
+		arr1.Set(kept)
+	}
+	applyOverrideAfter(arr1, arr2)
+}
+
+// aboveVersion reports whether obj has a MinVersion higher than version.
+func aboveVersion(obj reflect.Value, version string) bool {
+	minVersion := obj.FieldByName("MinVersion")
+	if !minVersion.IsValid() {
+		return false
+	}
+	for j := slices.Index(product.ORDER, version) + 1; j < len(product.ORDER); j++ {
+		if minVersion.String() == product.ORDER[j] {
+			return true
+		}
+	}
+	return false
+}
+
+// overrideRemoves reports whether an override element has `$remove: true`
+// and applies at version.
+func overrideRemoves(obj reflect.Value, version string) bool {
+	remove := obj.FieldByName("OverrideRemove")
+	return remove.IsValid() && remove.Bool() && !aboveVersion(obj, version)
+}
+
+// applyOverrideAfter moves the elements of merged that an override element
+// in overrides marks with `$after: name` to directly after the element
+// called name, in the order they appear in overrides. The directive is
+// cleared once applied; directives naming a missing element are left set
+// for Type.Validate to report.
+func applyOverrideAfter(merged, overrides reflect.Value) {
+	for i := 0; i < overrides.Len(); i++ {
+		other := reflect.Indirect(overrides.Index(i))
+		// Only named structs, such as properties, can be moved
+		if other.Kind() != reflect.Struct {
+			continue
+		}
+		after := other.FieldByName("OverrideAfter")
+		if !after.IsValid() || after.String() == "" {
+			continue
+		}
+		from := namedIndex(merged, other.FieldByName("Name").String())
+		to := namedIndex(merged, after.String())
+		if from < 0 || to < 0 || from == to {
+			continue
+		}
+
+		elem := merged.Index(from)
+		reflect.Indirect(elem).FieldByName("OverrideAfter").SetString("")
+		var order []reflect.Value
+		for j := 0; j < merged.Len(); j++ {
+			if j != from {
+				order = append(order, merged.Index(j))
+			}
+			if j == to {
+				order = append(order, elem)
+			}
+		}
+		reordered := reflect.MakeSlice(merged.Type(), 0, merged.Len())
+		reordered = reflect.Append(reordered, order...)
+		merged.Set(reordered)
+	}
+}
+
+// namedIndex returns the index of the element of arr with the given Name, or
+// -1 if there is none.
+func namedIndex(arr reflect.Value, name string) int {
+	for i := 0; i < arr.Len(); i++ {
+		if reflect.Indirect(arr.Index(i)).FieldByName("Name").String() == name {
+			return i
+		}
+	}
+	return -1
 }
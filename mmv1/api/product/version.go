@@ -14,7 +14,7 @@
 package product
 
 import (
-	"log"
+	"fmt"
 
 	"golang.org/x/exp/slices"
 )
@@ -32,13 +32,14 @@ type Version struct {
 	Name             string
 }
 
-func (v *Version) Validate(pName string) {
+func (v *Version) Validate(pName string) (es []error) {
 	if v.Name == "" {
-		log.Fatalf("Missing `name` in `version` for product %s", pName)
+		es = append(es, fmt.Errorf("missing `name` in `version` for product %s", pName))
 	}
 	if v.BaseUrl == "" {
-		log.Fatalf("Missing `base_url` in `version` for product %s", pName)
+		es = append(es, fmt.Errorf("missing `base_url` in `version` for product %s", pName))
 	}
+	return es
 }
 
 func (v *Version) CompareTo(other *Version) int {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"gopkg.in/yaml.v3"
 )
 
 func TestProductLowestVersion(t *testing.T) {
@@ -256,3 +257,196 @@ func TestProductServiceVersion(t *testing.T) {
 		})
 	}
 }
+
+// Override files can delete inherited properties with `$remove: true` and
+// reorder properties with `$after: fieldName`, at any level of nesting.
+func TestMergeOverrideDirectives(t *testing.T) {
+	t.Parallel()
+
+	base := `
+name: Topic
+properties:
+  - name: name
+    type: String
+  - name: labels
+    type: KeyValueLabels
+  - name: legacyField
+    type: String
+  - name: config
+    type: NestedObject
+    properties:
+      - name: mode
+        type: String
+      - name: size
+        type: Integer
+      - name: zone
+        type: String
+`
+
+	cases := []struct {
+		description string
+		version     string
+		override    string
+		expected    []string
+	}{
+		{
+			description: "remove an inherited property",
+			override: `
+properties:
+  - name: legacyField
+    $remove: true
+`,
+			expected: []string{"name", "labels", "config", "config.mode", "config.size", "config.zone"},
+		},
+		{
+			description: "move an inherited property",
+			override: `
+properties:
+  - name: labels
+    $after: config
+`,
+			expected: []string{"name", "legacyField", "config", "config.mode", "config.size", "config.zone", "labels"},
+		},
+		{
+			description: "insert a new property after an inherited one",
+			override: `
+properties:
+  - name: kmsKeyName
+    type: String
+    $after: name
+`,
+			expected: []string{"name", "kmsKeyName", "labels", "legacyField", "config", "config.mode", "config.size", "config.zone"},
+		},
+		{
+			description: "chained moves apply in order",
+			override: `
+properties:
+  - name: config
+    $after: name
+  - name: legacyField
+    $after: config
+`,
+			expected: []string{"name", "config", "config.mode", "config.size", "config.zone", "legacyField", "labels"},
+		},
+		{
+			description: "nested properties",
+			override: `
+properties:
+  - name: config
+    properties:
+      - name: size
+        $remove: true
+      - name: mode
+        $after: zone
+`,
+			expected: []string{"name", "labels", "legacyField", "config", "config.zone", "config.mode"},
+		},
+		{
+			description: "remove above the generated version is ignored",
+			override: `
+properties:
+  - name: legacyField
+    min_version: beta
+    $remove: true
+`,
+			expected: []string{"name", "labels", "legacyField", "config", "config.mode", "config.size", "config.zone"},
+		},
+		{
+			description: "remove at the generated version",
+			version:     "beta",
+			override: `
+properties:
+  - name: legacyField
+    min_version: beta
+    $remove: true
+`,
+			expected: []string{"name", "labels", "config", "config.mode", "config.size", "config.zone"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var r, override Resource
+			if err := yaml.Unmarshal([]byte(base), &r); err != nil {
+				t.Fatal(err)
+			}
+			if err := yaml.Unmarshal([]byte(tc.override), &override); err != nil {
+				t.Fatal(err)
+			}
+			version := tc.version
+			if version == "" {
+				version = "ga"
+			}
+			Merge(reflect.ValueOf(&r).Elem(), reflect.ValueOf(override), version)
+
+			var got []string
+			var walk func(prefix string, props []*Type)
+			walk = func(prefix string, props []*Type) {
+				for _, p := range props {
+					got = append(got, prefix+p.Name)
+					if p.OverrideRemove || p.OverrideAfter != "" {
+						t.Errorf("property %s still has override directives set", p.Name)
+					}
+					walk(prefix+p.Name+".", p.Properties)
+				}
+			}
+			walk("", r.Properties)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected properties %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestMergeOverrideDirectivesNotApplied(t *testing.T) {
+	t.Parallel()
+
+	r := Resource{Name: "Topic", ProductMetadata: &Product{Name: "Pubsub"}, Properties: []*Type{{Name: "name", Type: "String"}}}
+	override := Resource{Properties: []*Type{
+		{Name: "missing", Type: "String", OverrideRemove: true},
+		{Name: "name", OverrideAfter: "unknown"},
+	}}
+	Merge(reflect.ValueOf(&r).Elem(), reflect.ValueOf(override), "ga")
+
+	var es []error
+	for _, p := range r.Properties {
+		p.ResourceMetadata = &r
+		es = append(es, p.Validate(r.Name)...)
+	}
+	var got []string
+	for _, e := range es {
+		got = append(got, e.Error())
+	}
+	expected := []string{
+		"property name `$after: unknown` was not applied, it must be in an override file and match a sibling property in resource Topic",
+		"property missing `$remove` was not applied, it must be in an override file and match an inherited property in resource Topic",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected errors %q, got %q", expected, got)
+	}
+}
+
+func TestDeepMergeNonStructSlices(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		base, arr interface{}
+	}{
+		{name: "ints", base: &[]int{}, arr: []int{1, 2}},
+		{name: "maps", base: &[]map[string]string{}, arr: []map[string]string{{"key": "value"}}},
+		{name: "nil pointers", base: &[]*Type{}, arr: []*Type{nil}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			base := reflect.ValueOf(tc.base).Elem()
+			DeepMerge(base, reflect.ValueOf(tc.arr), "ga")
+			if !reflect.DeepEqual(base.Interface(), tc.arr) {
+				t.Errorf("expected %v, got %v", tc.arr, base.Interface())
+			}
+		})
+	}
+}
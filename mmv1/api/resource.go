@@ -13,6 +13,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"log"
@@ -239,6 +240,16 @@ type Resource struct {
 	// If true, resource is not importable
 	ExcludeImport bool `yaml:"exclude_import,omitempty"`
 
+	// If true, the resource doesn't have a Terraform resource identity. By
+	// default, importable resources have one, made of the fields of their long
+	// form import id.
+	ExcludeIdentity bool `yaml:"exclude_identity,omitempty"`
+
+	// If true, no list resource is generated for the resource. By default,
+	// resources with an identity that can be built from their collection URL
+	// and list response can be listed with `terraform query`.
+	ExcludeListResource bool `yaml:"exclude_list_resource,omitempty"`
+
 	// EXPERIMENTAL: If true, resource should be autogenerated as a data source
 	Datasource *resource.Datasource `yaml:"datasource_experimental,omitempty"`
 
@@ -322,10 +333,19 @@ type Resource struct {
 	// If true, generates product operation handling logic.
 	AutogenAsync bool `yaml:"autogen_async,omitempty"`
 
-	// EXPERIMENTAL: this is an incomplete feature and may have several build errors.
-	// If true, this resource generates with the new plugin framework resource template
+	// If true, this resource generates with the plugin framework resource
+	// template, resource_fw.go.tmpl, instead of the SDK one. The generated
+	// resource shares its CRUD functions, expanders and flatteners with SDK
+	// resources; features without a framework equivalent are reported by
+	// Validate.
 	FrameworkResource bool `yaml:"plugin_framework_experimental,omitempty"`
 
+	// If set, this resource generates a plugin framework ephemeral resource
+	// with ephemeral_resource.go.tmpl instead of a managed resource. Ephemeral
+	// resources are opened, and optionally renewed and closed, during a
+	// Terraform run and are never stored in state.
+	Ephemeral *resource.Ephemeral `yaml:"ephemeral,omitempty"`
+
 	ProductMetadata *Product `yaml:"-"`
 
 	// The version name provided by the user through CI
@@ -334,6 +354,10 @@ type Resource struct {
 	ImportPath     string `yaml:"-"`
 	SourceYamlFile string `yaml:"-"`
 
+	// Where the resource was declared in the YAML input. Points at the
+	// override file when the resource was changed by one.
+	SourcePos SourcePosition `yaml:"-"`
+
 	constraintGroupRegistry     map[string]*[]string `yaml:"-"`
 	constraintGroupsInitialized bool                 `yaml:"-"`
 
@@ -481,6 +505,9 @@ func (r *Resource) setShallowDefaults() {
 	if r.Timeouts == nil {
 		r.Timeouts = NewTimeouts() // This only sets defaults if Timeouts is nil
 	}
+	if r.Ephemeral != nil {
+		r.Ephemeral.SetDefault(r.SelfLinkUri())
+	}
 }
 
 // SetDefault sets default values for this Resource and all its properties.
@@ -500,6 +527,14 @@ func (r *Resource) SetDefault(product *Product) {
 	}
 }
 
+// The HTTP verbs allowed for each of a resource's operations.
+var (
+	CreateVerbs = []string{"POST", "PUT", "PATCH"}
+	ReadVerbs   = []string{"GET", "POST"}
+	UpdateVerbs = []string{"POST", "PUT", "PATCH"}
+	DeleteVerbs = []string{"POST", "PUT", "PATCH", "DELETE"}
+)
+
 func (r *Resource) Validate() (es []error) {
 	if r.Name == "" {
 		es = append(es, fmt.Errorf("missing `name` for resource"))
@@ -523,34 +558,30 @@ func (r *Resource) Validate() (es []error) {
 		es = append(es, fmt.Errorf("missing `description` for resource %s", r.Name))
 	}
 
+	if r.Samples != nil && r.Examples != nil {
+		es = append(es, fmt.Errorf("both `samples` and `examples` are set for resource %s", r.Name))
+	}
+
 	if !r.Exclude {
 		if len(r.Properties) == 0 {
 			es = append(es, fmt.Errorf("missing `properties` for resource %s", r.Name))
 		}
 	}
 
-	allowed := []string{"POST", "PUT", "PATCH"}
-	if !slices.Contains(allowed, r.CreateVerb) {
-		es = append(es, fmt.Errorf("value on `create_verb` should be one of %#v", allowed))
+	if !slices.Contains(CreateVerbs, r.CreateVerb) {
+		es = append(es, fmt.Errorf("value on `create_verb` should be one of %#v", CreateVerbs))
 	}
 
-	allowed = []string{"GET", "POST"}
-	if !slices.Contains(allowed, r.ReadVerb) {
-		es = append(es, fmt.Errorf("value on `read_verb` should be one of %#v", allowed))
+	if !slices.Contains(ReadVerbs, r.ReadVerb) {
+		es = append(es, fmt.Errorf("value on `read_verb` should be one of %#v", ReadVerbs))
 	}
 
-	allowed = []string{"POST", "PUT", "PATCH", "DELETE"}
-	if !slices.Contains(allowed, r.DeleteVerb) {
-		es = append(es, fmt.Errorf("value on `delete_verb` should be one of %#v", allowed))
+	if !slices.Contains(DeleteVerbs, r.DeleteVerb) {
+		es = append(es, fmt.Errorf("value on `delete_verb` should be one of %#v", DeleteVerbs))
 	}
 
-	allowed = []string{"POST", "PUT", "PATCH"}
-	if !slices.Contains(allowed, r.UpdateVerb) {
-		es = append(es, fmt.Errorf("value on `update_verb` should be one of %#v", allowed))
-	}
-
-	for _, property := range r.AllProperties() {
-		es = append(es, property.Validate(r.Name)...)
+	if !slices.Contains(UpdateVerbs, r.UpdateVerb) {
+		es = append(es, fmt.Errorf("value on `update_verb` should be one of %#v", UpdateVerbs))
 	}
 
 	if r.IamPolicy != nil {
@@ -561,6 +592,19 @@ func (r *Resource) Validate() (es []error) {
 		es = append(es, r.NestedQuery.Validate(r.Name)...)
 	}
 
+	if r.FrameworkResource {
+		es = append(es, r.validateFrameworkResource()...)
+	}
+
+	if r.Ephemeral != nil {
+		es = append(es, r.Ephemeral.Validate(r.Name)...)
+		es = append(es, r.validateEphemeral()...)
+	}
+
+	if r.ShouldGeneratePluralDataSource() {
+		es = append(es, r.validatePluralDataSource()...)
+	}
+
 	for _, example := range r.Examples {
 		if err := example.Validate(r.Name); err != nil {
 			es = append(es, err)
@@ -571,6 +615,134 @@ func (r *Resource) Validate() (es []error) {
 		es = append(es, sample.Validate(r.Name)...)
 	}
 
+	// Properties report their own positions, so only resource-level errors
+	// are annotated with the resource's position.
+	es = utils.TransformErrs(func(e error) error {
+		return fmt.Errorf("%w%s", e, r.SourcePos.errorSuffix())
+	}, es)
+
+	for _, property := range r.AllProperties() {
+		es = append(es, property.Validate(r.Name)...)
+	}
+
+	return es
+}
+
+// frameworkCustomDiffs are the custom_diff functions that resource_fw.go.tmpl
+// implements with a plan modifier.
+var frameworkCustomDiffs = []string{
+	"tpgresource.SetLabelsDiff",
+	"tpgresource.SetLabelsDiffWithoutAttributionLabel",
+	"tpgresource.SetAnnotationsDiff",
+}
+
+// validateFrameworkResource reports the features of a resource with
+// plugin_framework_experimental that have no plugin framework equivalent.
+func (r *Resource) validateFrameworkResource() (es []error) {
+	unsupported := func(feature string) {
+		es = append(es, fmt.Errorf("%s is not supported with `plugin_framework_experimental` in resource %s", feature, r.Name))
+	}
+
+	if r.NestedQuery != nil {
+		unsupported("`nested_query`")
+	}
+	if r.FieldSpecificUpdateMethods() {
+		unsupported("`update_url` on properties")
+	}
+	if r.StateUpgraders || r.MigrateState != "" {
+		unsupported("`state_upgraders` or `migrate_state`")
+	}
+	if r.CustomCode.CustomImport != "" {
+		unsupported("`custom_code.custom_import`")
+	}
+	if r.CustomCode.PostImport != "" {
+		unsupported("`custom_code.post_import`")
+	}
+	if r.CustomCode.ExtraSchemaEntry != "" {
+		unsupported("`custom_code.extra_schema_entry`")
+	}
+	if r.CustomCode.ValidateRawResourceConfigFuncs != "" {
+		unsupported("`custom_code.raw_resource_config_validation`")
+	}
+	for _, cdiff := range r.CustomDiff {
+		if !slices.Contains(frameworkCustomDiffs, cdiff) {
+			unsupported(fmt.Sprintf("`custom_diff` %s", cdiff))
+		}
+	}
+
+	for _, p := range r.AllNestedProperties(r.AllUserProperties()) {
+		switch {
+		case p.WriteOnly:
+			unsupported(fmt.Sprintf("`write_only` on property %s", p.Name))
+		case p.StateFunc != "":
+			unsupported(fmt.Sprintf("`state_func` on property %s", p.Name))
+		case p.UnorderedList:
+			unsupported(fmt.Sprintf("`unordered_list` on property %s", p.Name))
+		case p.DefaultFromApi && p.IsFWBlock():
+			unsupported(fmt.Sprintf("`default_from_api` on nested property %s", p.Name))
+		case p.DefaultValue != nil && slices.Contains([]string{"Array", "NestedObject", "Map"}, p.Type):
+			unsupported(fmt.Sprintf("`default_value` on non-primitive property %s", p.Name))
+		}
+	}
+	return es
+}
+
+// validateEphemeral reports the features of a resource with `ephemeral` that
+// only apply to managed resources.
+func (r *Resource) validateEphemeral() (es []error) {
+	unsupported := func(feature string) {
+		es = append(es, fmt.Errorf("%s is not supported with `ephemeral` in resource %s", feature, r.Name))
+	}
+
+	if r.FrameworkResource {
+		unsupported("`plugin_framework_experimental`")
+	}
+	if r.Datasource != nil {
+		unsupported("`datasource_experimental`")
+	}
+	if r.IamPolicy != nil {
+		unsupported("`iam_policy`")
+	}
+	if r.NestedQuery != nil {
+		unsupported("`nested_query`")
+	}
+	if r.AutogenAsync || (r.Async != nil && r.Async.Type != "") {
+		unsupported("`async`")
+	}
+	if r.VirtualFields != nil {
+		unsupported("`virtual_fields`")
+	}
+	if r.CustomCode != (resource.CustomCode{Constants: r.CustomCode.Constants, Encoder: r.CustomCode.Encoder, Decoder: r.CustomCode.Decoder}) {
+		unsupported("`custom_code` other than `constants`, `encoder` and `decoder`")
+	}
+
+	for _, p := range r.AllNestedProperties(r.AllUserProperties()) {
+		switch {
+		case p.WriteOnly:
+			unsupported(fmt.Sprintf("`write_only` on property %s", p.Name))
+		case p.StateFunc != "":
+			unsupported(fmt.Sprintf("`state_func` on property %s", p.Name))
+		case p.UnorderedList:
+			unsupported(fmt.Sprintf("`unordered_list` on property %s", p.Name))
+		case p.DefaultValue != nil && slices.Contains([]string{"Array", "NestedObject", "Map"}, p.Type):
+			unsupported(fmt.Sprintf("`default_value` on non-primitive property %s", p.Name))
+		}
+	}
+	return es
+}
+
+// validatePluralDataSource reports why a plural data source can't be
+// generated for the resource. Plural data sources list the resource's
+// collection with its list resource.
+func (r *Resource) validatePluralDataSource() (es []error) {
+	if r.FrameworkResource {
+		es = append(es, fmt.Errorf("`datasource_experimental.plural` is not supported with `plugin_framework_experimental` in resource %s", r.Name))
+	} else if !r.HasListResource() {
+		es = append(es, fmt.Errorf("`datasource_experimental.plural` requires the resource to have a list resource in resource %s", r.Name))
+	}
+	if google.Plural(r.Name) == r.Name {
+		es = append(es, fmt.Errorf("`datasource_experimental.plural` requires a singular `name` in resource %s", r.Name))
+	}
 	return es
 }
 
@@ -733,6 +905,15 @@ func (r Resource) GettableProperties() []*Type {
 	})
 }
 
+// EphemeralResultProperties returns the properties of an ephemeral resource
+// that are read from the response when it is opened. Other properties keep
+// their configured values.
+func (r Resource) EphemeralResultProperties() []*Type {
+	return google.Select(r.GettableProperties(), func(v *Type) bool {
+		return v.Output || v.DefaultFromApi
+	})
+}
+
 // Returns the list of top-level properties once any nested objects with flatten_object
 // set to true have been collapsed
 func (r Resource) RootProperties() []*Type {
@@ -894,12 +1075,12 @@ func buildWriteOnlyVersionField(name string, originalField *Type, writeOnlyField
 	return NewProperty(name, name, options)
 }
 
-func (r *Resource) addWriteOnlyFields(props []*Type, propWithWoConfigured *Type) []*Type {
-	propWithWoConfigured.WriteOnly = false
-	propWithWoConfigured.Sensitive = true
+func (r *Resource) addWriteOnlyFields(props []*Type, propWithWoConfigured *Type) ([]*Type, error) {
 	if len(propWithWoConfigured.RequiredWith) > 0 {
-		log.Fatalf("WriteOnly property '%s' in resource '%s' cannot have RequiredWith set. This combination is not supported.", propWithWoConfigured.Name, r.Name)
+		return props, fmt.Errorf("write_only property '%s' in resource '%s' cannot have required_with set. This combination is not supported", propWithWoConfigured.Name, r.Name)
 	}
+	propWithWoConfigured.WriteOnly = false
+	propWithWoConfigured.Sensitive = true
 	// Don't add write only fields to tgc, as write only fields don't exist in tfplan json,
 	// the input of tfplan2cai.
 	if !strings.Contains(r.ProductMetadata.Compiler, "terraformgoogleconversion") {
@@ -909,29 +1090,38 @@ func (r *Resource) addWriteOnlyFields(props []*Type, propWithWoConfigured *Type)
 		writeOnlyVersionField := buildWriteOnlyVersionField(woVersionFieldName, propWithWoConfigured, writeOnlyField)
 		props = append(props, writeOnlyField, writeOnlyVersionField)
 	}
-	return props
+	return props, nil
 }
 
 // AddExtraFields processes properties and adds supplementary fields based on property types.
 // It handles write-only properties, labels, and annotations.
-func (r *Resource) AddExtraFields(props []*Type, parent *Type) []*Type {
+func (r *Resource) AddExtraFields(props []*Type, parent *Type) ([]*Type, error) {
 	if !r.constraintGroupsInitialized {
 		r.initializeConstraintGroups()
 	}
 
+	var es []error
 	for _, p := range props {
 		if p.WriteOnly && !strings.HasSuffix(p.Name, "Wo") {
-			props = r.addWriteOnlyFields(props, p)
+			var err error
+			props, err = r.addWriteOnlyFields(props, p)
+			if err != nil {
+				es = append(es, err)
+			}
 		}
 		if p.IsA("KeyValueLabels") {
 			props = r.addLabelsFields(props, parent, p)
 		} else if p.IsA("KeyValueAnnotations") {
 			props = r.addAnnotationsFields(props, parent, p)
 		} else if p.IsA("NestedObject") && len(p.AllProperties()) > 0 {
-			p.Properties = r.AddExtraFields(p.AllProperties(), p)
+			var err error
+			p.Properties, err = r.AddExtraFields(p.AllProperties(), p)
+			if err != nil {
+				es = append(es, err)
+			}
 		}
 	}
-	return props
+	return props, errors.Join(es...)
 }
 
 func (r *Resource) addLabelsFields(props []*Type, parent *Type, labels *Type) []*Type {
@@ -1966,6 +2156,112 @@ func (r Resource) LastNestedQueryKey() string {
 	return r.NestedQuery.Keys[len-1]
 }
 
+// HasIdentity reports whether the resource has a Terraform resource identity.
+// The identity is set when the resource is read, so resources that aren't read
+// after they're created, or can't be imported, don't have one.
+func (r Resource) HasIdentity() bool {
+	if r.ExcludeIdentity || r.ExcludeImport || r.ExcludeRead || r.FrameworkResource || r.Ephemeral != nil {
+		return false
+	}
+	return r.CustomCode.CustomCreate == "" && len(r.IdentityFields()) > 0
+}
+
+// IdentityImportFormat returns the import id format that the resource identity
+// is built from, such as projects/{{project}}/global/networks/{{name}}.
+func (r Resource) IdentityImportFormat() string {
+	return strings.ReplaceAll(r.ImportIdFormatsFromResource()[0], "{{%", "{{")
+}
+
+// IdentityFields returns the names of the resource identity attributes, which
+// are the fields of the long form import id.
+func (r Resource) IdentityFields() []string {
+	var fields []string
+	for _, m := range regexp.MustCompile(`{{%?([[:word:]]+)}}`).FindAllStringSubmatch(r.ImportIdFormatsFromResource()[0], -1) {
+		if !slices.Contains(fields, m[1]) {
+			fields = append(fields, m[1])
+		}
+	}
+	return fields
+}
+
+// IsIdentityFieldOptional reports whether a resource identity attribute can be
+// left out on import, because it has a provider-level default.
+func (r Resource) IsIdentityFieldOptional(field string) bool {
+	return field == "project" || field == "region" || field == "zone"
+}
+
+// HasListResource reports whether a list resource is generated for the
+// resource. Listed resources are read from their collection URL, so every
+// identity field must be a collection URL parameter or returned by the API.
+func (r Resource) HasListResource() bool {
+	if r.ExcludeListResource || !r.HasIdentity() || r.ReadVerb != "GET" {
+		return false
+	}
+	if r.NestedQuery != nil && r.NestedQuery.IsListOfIds {
+		return false
+	}
+	// Custom read code can't run without the resource's own read request.
+	if r.CustomCode.PreRead != "" || r.CustomCode.PostRead != "" {
+		return false
+	}
+	// Singleton resources don't have a collection to list.
+	if r.SelfLinkUri() == r.collectionUri() {
+		return false
+	}
+
+	args := r.ListResourceArguments()
+	// filter is the argument for the list method's filter expression.
+	if slices.Contains(args, "filter") {
+		return false
+	}
+	for _, field := range r.IdentityFields() {
+		if slices.Contains(args, field) || field == r.ListResourceIdField() {
+			continue
+		}
+		if !slices.ContainsFunc(r.GettableProperties(), func(p *Type) bool {
+			return google.Underscore(p.Name) == field
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// ListResourceArguments returns the parameters of the resource's collection
+// URL, which are the arguments of its list resource.
+func (r Resource) ListResourceArguments() []string {
+	var args []string
+	for _, m := range regexp.MustCompile(`{{%?([[:word:]]+)}}`).FindAllStringSubmatch(r.collectionUri(), -1) {
+		if !slices.Contains(args, m[1]) {
+			args = append(args, m[1])
+		}
+	}
+	return args
+}
+
+// ListResourceIdField returns the url_param_only field at the end of the
+// resource's self link, such as cluster_id, that a listed resource's id is
+// taken from. It is the last segment of the name returned by the API. It's
+// empty if the resource doesn't have one.
+func (r Resource) ListResourceIdField() string {
+	m := regexp.MustCompile(`/{{([[:word:]]+)}}$`).FindStringSubmatch(r.SelfLinkUri())
+	if m == nil {
+		return ""
+	}
+	if !slices.ContainsFunc(r.AllUserProperties(), func(p *Type) bool {
+		return p.UrlParamOnly && google.Underscore(p.Name) == m[1]
+	}) {
+		return ""
+	}
+	return m[1]
+}
+
+// IsListResourceArgumentOptional reports whether a list resource argument can
+// be left out, because it has a provider-level default.
+func (r Resource) IsListResourceArgumentOptional(arg string) bool {
+	return r.IsIdentityFieldOptional(arg)
+}
+
 func (r Resource) FirstIdentityProp() *Type {
 	idProps := r.GetIdentity()
 	if len(idProps) == 0 {
@@ -2062,6 +2358,21 @@ func (r Resource) TestExamples() []*resource.Examples {
 	})
 }
 
+// IsIdentityTestExample reports whether e is the test example that also
+// checks the resource identity and imports the resource by identity, the
+// first test example that imports the resource.
+func (r Resource) IsIdentityTestExample(e *resource.Examples) bool {
+	if !r.HasIdentity() {
+		return false
+	}
+	for _, te := range r.TestExamples() {
+		if !te.ExcludeImportTest && te.ResourceType(r.TerraformName()) == r.TerraformName() {
+			return te == e
+		}
+	}
+	return false
+}
+
 func (r Resource) TestSamples() []*resource.Sample {
 	return google.Reject(google.Reject(r.Samples, func(s *resource.Sample) bool {
 		return s.ExcludeTest
@@ -2070,7 +2381,8 @@ func (r Resource) TestSamples() []*resource.Sample {
 	})
 }
 
-func (r Resource) TestSampleSetUp(sysfs fs.FS) {
+func (r Resource) TestSampleSetUp(sysfs fs.FS) error {
+	var es []error
 	res := make(map[string]string)
 	for _, sample := range r.Samples {
 		sample.TargetVersionName = r.TargetVersionName
@@ -2086,7 +2398,10 @@ func (r Resource) TestSampleSetUp(sysfs fs.FS) {
 			if step.ConfigPath == "" {
 				step.ConfigPath = fmt.Sprintf("templates/terraform/samples/services/%s/%s.tf.tmpl", packageName, step.Name)
 			}
-			step.SetHCLText(sysfs)
+			if err := step.SetHCLText(sysfs); err != nil {
+				es = append(es, fmt.Errorf("sample %s step %s: %w", sample.Name, step.Name, err))
+				continue
+			}
 			configName := step.Name
 			if _, ok := res[step.Name]; !ok {
 				res[configName] = sample.Name
@@ -2094,6 +2409,7 @@ func (r Resource) TestSampleSetUp(sysfs fs.FS) {
 			}
 		}
 	}
+	return errors.Join(es...)
 }
 
 func (r Resource) VersionedProvider(exampleVersion string) bool {
@@ -2396,6 +2712,35 @@ func (r *Resource) ShouldGenerateSingularDataSourceTests() bool {
 	return !r.Datasource.ExcludeTest
 }
 
+func (r *Resource) ShouldGeneratePluralDataSource() bool {
+	if r.Datasource == nil {
+		return false
+	}
+	return r.Datasource.Plural
+}
+
+// PluralDataSourceName returns the name of the resource's plural data source,
+// e.g. google_compute_addresses.
+func (r Resource) PluralDataSourceName() string {
+	return google.Plural(r.TerraformName())
+}
+
+// PluralDataSourceItemsField returns the attribute of the plural data source
+// that holds the listed resources, e.g. addresses.
+func (r Resource) PluralDataSourceItemsField() string {
+	return google.Underscore(google.Plural(r.Name))
+}
+
+// PluralDataSourceKeyField returns the field that identifies a listed resource
+// within its collection, the last field of its identity.
+func (r Resource) PluralDataSourceKeyField() string {
+	fields := r.IdentityFields()
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
+
 func (r Resource) ShouldDatasourceSetLabels() bool {
 	for _, p := range r.Properties {
 		if p.Name == "labels" && p.Type == "KeyValueLabels" {
@@ -2470,6 +2815,13 @@ func (r Resource) GithubURL() string {
 func (r Resource) CodeHeader(templatePath string) string {
 	templateUrl := GITHUB_BASE_URL + templatePath
 
+	configuration := r.GithubURL()
+	if r.SourcePos.Override {
+		configuration = fmt.Sprintf("%s\n//     Override:      %s#L%d", configuration, r.SourcePos.Path, r.SourcePos.Line)
+	} else if r.SourcePos.Line > 0 {
+		configuration = fmt.Sprintf("%s#L%d", configuration, r.SourcePos.Line)
+	}
+
 	return fmt.Sprintf(`// ----------------------------------------------------------------------------
 //
 //     ***     AUTO GENERATED CODE    ***    Type: MMv1     ***
@@ -2484,7 +2836,7 @@ func (r Resource) CodeHeader(templatePath string) string {
 //     DO NOT EDIT this file directly. Any changes made to this file will be
 //     overwritten during the next generation cycle.
 //
-// ----------------------------------------------------------------------------`, r.GithubURL(), templateUrl)
+// ----------------------------------------------------------------------------`, configuration, templateUrl)
 }
 
 func (r Resource) MarkdownHeader(templatePath string) string {
@@ -2514,10 +2866,6 @@ func (r Resource) TGCTestIgnorePropertiesToStrings() []string {
 		}
 	}
 
-	if r.Samples != nil && r.Examples != nil {
-		log.Fatalf("Both Samples and Examples block exist in %v", r.Name)
-	}
-
 	if r.Examples != nil {
 		for _, e := range r.Examples {
 			for _, p := range e.IgnoreReadExtra {
@@ -18,4 +18,7 @@ type Datasource struct {
 	Generate bool `yaml:"generate"`
 	// boolean to determine whether tests should be generated for a datasource
 	ExcludeTest bool `yaml:"exclude_test"`
+	// boolean to determine whether a plural data source, which lists the
+	// resources of a collection, should be generated
+	Plural bool `yaml:"plural"`
 }
@@ -0,0 +1,95 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"slices"
+)
+
+// The HTTP verbs allowed for each of an ephemeral resource's operations.
+var (
+	EphemeralOpenVerbs  = []string{"GET", "POST"}
+	EphemeralRenewVerbs = []string{"POST", "PUT", "PATCH"}
+	EphemeralCloseVerbs = []string{"POST", "DELETE"}
+)
+
+// Metadata for ephemeral resources, which are opened for the duration of a
+// Terraform run and never stored in state. e.g. short-lived credentials from
+// methods like generateAccessToken or secretVersions:access
+//
+// Properties that aren't output-only are sent when the resource is opened, and
+// output-only properties are read from the response.
+type Ephemeral struct {
+	// The URL the resource is opened with, relative to the product's base
+	// URL. Defaults to the resource's self_link.
+	OpenUrl string `yaml:"open_url,omitempty"`
+
+	// The HTTP verb used to open the resource. Defaults to POST. Properties are
+	// only sent in the request body when the verb isn't GET.
+	OpenVerb string `yaml:"open_verb,omitempty"`
+
+	// The URL the resource is renewed with before it expires, if it can be
+	// renewed. It is expanded when the resource is opened, so it can refer to
+	// fields of the open response.
+	RenewUrl string `yaml:"renew_url,omitempty"`
+
+	// The HTTP verb used to renew the resource. Defaults to POST.
+	RenewVerb string `yaml:"renew_verb,omitempty"`
+
+	// The URL the resource is closed with when Terraform is done with it, e.g.
+	// to revoke a credential. It is expanded when the resource is opened, so
+	// it can refer to fields of the open response.
+	CloseUrl string `yaml:"close_url,omitempty"`
+
+	// The HTTP verb used to close the resource. Defaults to DELETE.
+	CloseVerb string `yaml:"close_verb,omitempty"`
+
+	// The API name of the top-level field in the open and renew responses
+	// holding the RFC3339 time the resource expires at. Required with
+	// renew_url, as renewals are scheduled before this time.
+	ExpireTimeField string `yaml:"expire_time_field,omitempty"`
+}
+
+func (e *Ephemeral) SetDefault(selfLink string) {
+	if e.OpenUrl == "" {
+		e.OpenUrl = selfLink
+	}
+	if e.OpenVerb == "" {
+		e.OpenVerb = "POST"
+	}
+	if e.RenewVerb == "" {
+		e.RenewVerb = "POST"
+	}
+	if e.CloseVerb == "" {
+		e.CloseVerb = "DELETE"
+	}
+}
+
+func (e *Ephemeral) Validate(rName string) (es []error) {
+	if !slices.Contains(EphemeralOpenVerbs, e.OpenVerb) {
+		es = append(es, fmt.Errorf("value on `ephemeral.open_verb` should be one of %#v in resource %s", EphemeralOpenVerbs, rName))
+	}
+	if !slices.Contains(EphemeralRenewVerbs, e.RenewVerb) {
+		es = append(es, fmt.Errorf("value on `ephemeral.renew_verb` should be one of %#v in resource %s", EphemeralRenewVerbs, rName))
+	}
+	if !slices.Contains(EphemeralCloseVerbs, e.CloseVerb) {
+		es = append(es, fmt.Errorf("value on `ephemeral.close_verb` should be one of %#v in resource %s", EphemeralCloseVerbs, rName))
+	}
+	if e.RenewUrl != "" && e.ExpireTimeField == "" {
+		es = append(es, fmt.Errorf("missing `ephemeral.expire_time_field` for `ephemeral.renew_url` in resource %s", rName))
+	}
+
+	return es
+}
@@ -357,9 +357,13 @@ func (e *Examples) ExecuteTemplate(sysfs fs.FS) (string, error) {
 
 	// Check that any variables in Vars or TestEnvVars used in the example are defined via YAML
 	envVarRegex := regexp.MustCompile(`{{index \$\.TestEnvVars "([a-zA-Z_]*)"}}`)
-	validateRegexForContents(envVarRegex, fileContentString, e.ConfigPath, "test_env_vars", e.TestEnvVars)
+	if err := validateRegexForContents(envVarRegex, fileContentString, e.ConfigPath, "test_env_vars", e.TestEnvVars); err != nil {
+		return "", err
+	}
 	varRegex := regexp.MustCompile(`{{index \$\.Vars "([a-zA-Z_]*)"}}`)
-	validateRegexForContents(varRegex, fileContentString, e.ConfigPath, "vars", e.Vars)
+	if err := validateRegexForContents(varRegex, fileContentString, e.ConfigPath, "vars", e.Vars); err != nil {
+		return "", err
+	}
 
 	templateFileName := filepath.Base(e.ConfigPath)
 	tmpl, err := template.New(templateFileName).Funcs(google.TemplateFunctions(sysfs)).Parse(fileContentString)
@@ -142,6 +142,13 @@ func newIamPolicyWithDefaults() IamPolicy {
 	}
 }
 
+// NewIamPolicy returns an IamPolicy with the same defaults as one decoded
+// from YAML. It is only used in openapi-generate.
+func NewIamPolicy() *IamPolicy {
+	p := newIamPolicyWithDefaults()
+	return &p
+}
+
 // UnmarshalYAML implements a custom unmarshaler for the IamPolicy struct.
 // It sets default values and then decodes the YAML over them.
 func (p *IamPolicy) UnmarshalYAML(value *yaml.Node) error {
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 )
 
 func TestResourceMinVersionObj(t *testing.T) {
@@ -395,6 +396,378 @@ func TestHasPostCreateComputedFields(t *testing.T) {
 	}
 }
 
+func TestResourceIdentity(t *testing.T) {
+	cases := []struct {
+		name         string
+		resource     Resource
+		wantIdentity bool
+		wantFields   []string
+		wantFormat   string
+	}{
+		{
+			name: "default import format",
+			resource: Resource{
+				BaseUrl: "projects/{{project}}/locations/{{location}}/widgets",
+			},
+			wantIdentity: true,
+			wantFields:   []string{"project", "location", "name"},
+			wantFormat:   "projects/{{project}}/locations/{{location}}/widgets/{{name}}",
+		},
+		{
+			name: "import format with a field containing slashes",
+			resource: Resource{
+				BaseUrl:      "{{parent}}/widgets",
+				ImportFormat: []string{"{{%parent}}/widgets/{{widget_id}}"},
+			},
+			wantIdentity: true,
+			wantFields:   []string{"parent", "widget_id"},
+			wantFormat:   "{{parent}}/widgets/{{widget_id}}",
+		},
+		{
+			name: "excluded identity",
+			resource: Resource{
+				BaseUrl:         "projects/{{project}}/widgets",
+				ExcludeIdentity: true,
+			},
+			wantFields: []string{"project", "name"},
+			wantFormat: "projects/{{project}}/widgets/{{name}}",
+		},
+		{
+			name: "excluded read",
+			resource: Resource{
+				BaseUrl:     "projects/{{project}}/widgets",
+				ExcludeRead: true,
+			},
+			wantFields: []string{"project", "name"},
+			wantFormat: "projects/{{project}}/widgets/{{name}}",
+		},
+		{
+			name: "ephemeral",
+			resource: Resource{
+				BaseUrl:   "projects/{{project}}/widgets",
+				Ephemeral: &resource.Ephemeral{},
+			},
+			wantFields: []string{"project", "name"},
+			wantFormat: "projects/{{project}}/widgets/{{name}}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.resource.HasIdentity(); got != tc.wantIdentity {
+				t.Errorf("HasIdentity() = %t, want %t", got, tc.wantIdentity)
+			}
+			if got := tc.resource.IdentityFields(); !slices.Equal(got, tc.wantFields) {
+				t.Errorf("IdentityFields() = %v, want %v", got, tc.wantFields)
+			}
+			if got := tc.resource.IdentityImportFormat(); got != tc.wantFormat {
+				t.Errorf("IdentityImportFormat() = %q, want %q", got, tc.wantFormat)
+			}
+		})
+	}
+}
+
+func TestResourceListResource(t *testing.T) {
+	cases := []struct {
+		name          string
+		resource      Resource
+		wantList      bool
+		wantArguments []string
+		wantIdField   string
+	}{
+		{
+			name: "identity returned by the API",
+			resource: Resource{
+				BaseUrl:  "projects/{{project}}/locations/{{location}}/widgets",
+				ReadVerb: "GET",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+			wantList:      true,
+			wantArguments: []string{"project", "location"},
+		},
+		{
+			name: "id taken from the name returned by the API",
+			resource: Resource{
+				BaseUrl:      "projects/{{project}}/widgets",
+				SelfLink:     "projects/{{project}}/widgets/{{widget_id}}",
+				ImportFormat: []string{"projects/{{project}}/widgets/{{widget_id}}"},
+				ReadVerb:     "GET",
+				Parameters: []*Type{
+					{Name: "widgetId", Type: "String", UrlParamOnly: true},
+				},
+			},
+			wantList:      true,
+			wantArguments: []string{"project"},
+			wantIdField:   "widget_id",
+		},
+		{
+			name: "identity field missing from the API response",
+			resource: Resource{
+				BaseUrl:      "projects/{{project}}/widgets",
+				ImportFormat: []string{"projects/{{project}}/widgets/{{name}}/{{version}}"},
+				ReadVerb:     "GET",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+					{Name: "version", Type: "String", IgnoreRead: true},
+				},
+			},
+			wantArguments: []string{"project"},
+		},
+		{
+			name: "singleton",
+			resource: Resource{
+				BaseUrl:  "projects/{{project}}/widgetSettings",
+				SelfLink: "projects/{{project}}/widgetSettings",
+				ReadVerb: "GET",
+			},
+			wantArguments: []string{"project"},
+		},
+		{
+			name: "excluded list resource",
+			resource: Resource{
+				BaseUrl:             "projects/{{project}}/widgets",
+				ReadVerb:            "GET",
+				ExcludeListResource: true,
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+			wantArguments: []string{"project"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.resource.HasListResource(); got != tc.wantList {
+				t.Errorf("HasListResource() = %t, want %t", got, tc.wantList)
+			}
+			if got := tc.resource.ListResourceArguments(); !slices.Equal(got, tc.wantArguments) {
+				t.Errorf("ListResourceArguments() = %v, want %v", got, tc.wantArguments)
+			}
+			if got := tc.resource.ListResourceIdField(); got != tc.wantIdField {
+				t.Errorf("ListResourceIdField() = %q, want %q", got, tc.wantIdField)
+			}
+		})
+	}
+}
+
+func TestResourcePluralDataSource(t *testing.T) {
+	cases := []struct {
+		name       string
+		resource   Resource
+		wantName   string
+		wantItems  string
+		wantKey    string
+		wantErrors int
+	}{
+		{
+			name: "listable resource",
+			resource: Resource{
+				Name:         "Widget",
+				BaseUrl:      "projects/{{project}}/widgets",
+				SelfLink:     "projects/{{project}}/widgets/{{widget_id}}",
+				ImportFormat: []string{"projects/{{project}}/widgets/{{widget_id}}"},
+				ReadVerb:     "GET",
+				Parameters: []*Type{
+					{Name: "widgetId", Type: "String", UrlParamOnly: true},
+				},
+			},
+			wantName:  "google_widgets_widgets",
+			wantItems: "widgets",
+			wantKey:   "widget_id",
+		},
+		{
+			name: "pluralized name",
+			resource: Resource{
+				Name:     "WidgetPolicy",
+				BaseUrl:  "projects/{{project}}/widgetPolicies",
+				ReadVerb: "GET",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+			wantName:  "google_widgets_widget_policies",
+			wantItems: "widget_policies",
+			wantKey:   "name",
+		},
+		{
+			name: "resource without a list resource",
+			resource: Resource{
+				Name:                "Widget",
+				BaseUrl:             "projects/{{project}}/widgets",
+				ReadVerb:            "GET",
+				ExcludeListResource: true,
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+			wantName:   "google_widgets_widgets",
+			wantItems:  "widgets",
+			wantKey:    "name",
+			wantErrors: 1,
+		},
+		{
+			name: "plural resource name",
+			resource: Resource{
+				Name:     "WidgetPolicies",
+				BaseUrl:  "projects/{{project}}/widgetPolicies",
+				ReadVerb: "GET",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+			wantName:   "google_widgets_widget_policies",
+			wantItems:  "widget_policies",
+			wantKey:    "name",
+			wantErrors: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := tc.resource
+			r.ProductMetadata = &Product{Name: "Widgets"}
+			r.Datasource = &resource.Datasource{Plural: true}
+
+			if got := r.PluralDataSourceName(); got != tc.wantName {
+				t.Errorf("PluralDataSourceName() = %q, want %q", got, tc.wantName)
+			}
+			if got := r.PluralDataSourceItemsField(); got != tc.wantItems {
+				t.Errorf("PluralDataSourceItemsField() = %q, want %q", got, tc.wantItems)
+			}
+			if got := r.PluralDataSourceKeyField(); got != tc.wantKey {
+				t.Errorf("PluralDataSourceKeyField() = %q, want %q", got, tc.wantKey)
+			}
+			if es := r.validatePluralDataSource(); len(es) != tc.wantErrors {
+				t.Errorf("got %d validation errors %v, want %d", len(es), es, tc.wantErrors)
+			}
+		})
+	}
+}
+
+func TestResourceEphemeral(t *testing.T) {
+	cases := []struct {
+		name       string
+		resource   Resource
+		wantErrors int
+	}{
+		{
+			name: "defaults",
+			resource: Resource{
+				Name:      "WidgetToken",
+				BaseUrl:   "projects/{{project}}/widgets/{{widget}}:generateToken",
+				Ephemeral: &resource.Ephemeral{},
+			},
+		},
+		{
+			name: "renewable",
+			resource: Resource{
+				Name:    "WidgetToken",
+				BaseUrl: "projects/{{project}}/widgets/{{widget}}:generateToken",
+				Ephemeral: &resource.Ephemeral{
+					RenewUrl:        "{{name}}:renew",
+					ExpireTimeField: "expireTime",
+				},
+			},
+		},
+		{
+			name: "renewable without an expire time",
+			resource: Resource{
+				Name:    "WidgetToken",
+				BaseUrl: "projects/{{project}}/widgets/{{widget}}:generateToken",
+				Ephemeral: &resource.Ephemeral{
+					RenewUrl: "{{name}}:renew",
+				},
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "unsupported verbs",
+			resource: Resource{
+				Name:    "WidgetToken",
+				BaseUrl: "projects/{{project}}/widgets/{{widget}}:generateToken",
+				Ephemeral: &resource.Ephemeral{
+					OpenVerb:  "PUT",
+					CloseVerb: "GET",
+				},
+			},
+			wantErrors: 2,
+		},
+		{
+			name: "unsupported features",
+			resource: Resource{
+				Name:              "WidgetToken",
+				BaseUrl:           "projects/{{project}}/widgets/{{widget}}:generateToken",
+				FrameworkResource: true,
+				CustomCode: resource.CustomCode{
+					Encoder:    "templates/terraform/encoders/widget_token.go.tmpl",
+					PostCreate: "templates/terraform/post_create/widget_token.go.tmpl",
+				},
+				Properties: []*Type{
+					{
+						Name:      "secret",
+						Type:      "String",
+						WriteOnly: true,
+					},
+				},
+				Ephemeral: &resource.Ephemeral{},
+			},
+			wantErrors: 3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := tc.resource
+			r.setShallowDefaults()
+			if r.Ephemeral.OpenUrl != r.SelfLinkUri() {
+				t.Errorf("Ephemeral.OpenUrl = %q, want the self link %q", r.Ephemeral.OpenUrl, r.SelfLinkUri())
+			}
+
+			es := append(r.Ephemeral.Validate(r.Name), r.validateEphemeral()...)
+			if len(es) != tc.wantErrors {
+				t.Errorf("got %d validation errors %v, want %d", len(es), es, tc.wantErrors)
+			}
+		})
+	}
+}
+
+func TestIsIdentityTestExample(t *testing.T) {
+	t.Parallel()
+
+	noImport := &resource.Examples{Name: "widget_no_import", ExcludeImportTest: true}
+	other := &resource.Examples{Name: "widget_other", PrimaryResourceType: "google_other"}
+	basic := &resource.Examples{Name: "widget_basic"}
+	full := &resource.Examples{Name: "widget_full"}
+	r := Resource{
+		Name:            "Widget",
+		BaseUrl:         "projects/{{project}}/widgets",
+		ProductMetadata: &Product{Name: "Widgets"},
+		Examples:        []*resource.Examples{noImport, other, basic, full},
+	}
+
+	for _, e := range r.Examples {
+		if got, want := r.IsIdentityTestExample(e), e == basic; got != want {
+			t.Errorf("IsIdentityTestExample(%s) = %t, want %t", e.Name, got, want)
+		}
+	}
+
+	r.ExcludeIdentity = true
+	if r.IsIdentityTestExample(basic) {
+		t.Errorf("IsIdentityTestExample(%s) = true for a resource without identity", basic.Name)
+	}
+}
+
 func TestResourceAddExtraFields(t *testing.T) {
 	t.Parallel()
 
@@ -449,7 +822,10 @@ func TestResourceAddExtraFields(t *testing.T) {
 		)
 
 		props := []*Type{writeOnlyProp}
-		result := resource.AddExtraFields(props, nil)
+		result, err := resource.AddExtraFields(props, nil)
+		if err != nil {
+			t.Fatalf("AddExtraFields() returned error: %v", err)
+		}
 
 		if len(result) != 3 {
 			t.Errorf("Expected 3 properties after adding WriteOnly fields, got %d", len(result))
@@ -497,7 +873,10 @@ func TestResourceAddExtraFields(t *testing.T) {
 		)
 
 		props := []*Type{writeOnlyProp}
-		result := resource.AddExtraFields(props, nil)
+		result, err := resource.AddExtraFields(props, nil)
+		if err != nil {
+			t.Fatalf("AddExtraFields() returned error: %v", err)
+		}
 
 		if len(result) != 1 {
 			t.Errorf("Expected 1 property as WriteOnly fields should not be added, got %d", len(result))
@@ -508,6 +887,27 @@ func TestResourceAddExtraFields(t *testing.T) {
 		}
 	})
 
+	t.Run("WriteOnly property with RequiredWith returns an error", func(t *testing.T) {
+		t.Parallel()
+
+		resource := createTestResource("testresource", "terraform")
+		writeOnlyProp := createTestType("password", "String",
+			withWriteOnly(true),
+			withDescription("A password field"),
+		)
+		writeOnlyProp.RequiredWith = []string{"username"}
+
+		props := []*Type{writeOnlyProp}
+		result, err := resource.AddExtraFields(props, nil)
+		if err == nil {
+			t.Fatal("Expected an error for WriteOnly property with RequiredWith")
+		}
+
+		if len(result) != 1 {
+			t.Errorf("Expected 1 property as WriteOnly fields should not be added, got %d", len(result))
+		}
+	})
+
 	t.Run("KeyValueLabels property adds terraform and effective labels", func(t *testing.T) {
 		t.Parallel()
 
@@ -519,7 +919,10 @@ func TestResourceAddExtraFields(t *testing.T) {
 		}
 
 		props := []*Type{labelsType}
-		result := resource.AddExtraFields(props, nil)
+		result, err := resource.AddExtraFields(props, nil)
+		if err != nil {
+			t.Fatalf("AddExtraFields() returned error: %v", err)
+		}
 
 		if len(result) != 3 {
 			t.Errorf("Expected 3 properties after adding labels fields, got %d", len(result))
@@ -573,7 +976,9 @@ func TestResourceAddExtraFields(t *testing.T) {
 		}
 
 		props := []*Type{labelsType}
-		resource.AddExtraFields(props, nil)
+		if _, err := resource.AddExtraFields(props, nil); err != nil {
+			t.Fatalf("AddExtraFields() returned error: %v", err)
+		}
 
 		expectedDiff := "tpgresource.SetLabelsDiffWithoutAttributionLabel"
 		if !slices.Contains(resource.CustomDiff, expectedDiff) {
@@ -593,7 +998,9 @@ func TestResourceAddExtraFields(t *testing.T) {
 		}
 
 		props := []*Type{labelsType}
-		resource.AddExtraFields(props, parent)
+		if _, err := resource.AddExtraFields(props, parent); err != nil {
+			t.Fatalf("AddExtraFields() returned error: %v", err)
+		}
 
 		expectedDiff := "tpgresource.SetMetadataLabelsDiff"
 		if !slices.Contains(resource.CustomDiff, expectedDiff) {
@@ -612,7 +1019,10 @@ func TestResourceAddExtraFields(t *testing.T) {
 		}
 
 		props := []*Type{annotationsType}
-		result := resource.AddExtraFields(props, nil)
+		result, err := resource.AddExtraFields(props, nil)
+		if err != nil {
+			t.Fatalf("AddExtraFields() returned error: %v", err)
+		}
 
 		if len(result) != 2 {
 			t.Errorf("Expected 2 properties after adding annotations fields, got %d", len(result))
@@ -651,7 +1061,10 @@ func TestResourceAddExtraFields(t *testing.T) {
 		nestedObject := createTestType("config", "NestedObject", withProperties([]*Type{nestedWriteOnly}))
 
 		props := []*Type{nestedObject}
-		result := resource.AddExtraFields(props, nil)
+		result, err := resource.AddExtraFields(props, nil)
+		if err != nil {
+			t.Fatalf("AddExtraFields() returned error: %v", err)
+		}
 
 		if len(result) != 1 {
 			t.Errorf("Expected 1 top-level property, got %d", len(result))
@@ -673,7 +1086,10 @@ func TestResourceAddExtraFields(t *testing.T) {
 		emptyNestedObject := createTestType("config", "NestedObject", withProperties([]*Type{}))
 
 		props := []*Type{emptyNestedObject}
-		result := resource.AddExtraFields(props, nil)
+		result, err := resource.AddExtraFields(props, nil)
+		if err != nil {
+			t.Fatalf("AddExtraFields() returned error: %v", err)
+		}
 
 		if len(result) != 1 {
 			t.Errorf("Expected 1 property, got %d", len(result))
@@ -690,7 +1106,10 @@ func TestResourceAddExtraFields(t *testing.T) {
 		woProperty := createTestType("passwordWo", "String", withWriteOnly(true))
 
 		props := []*Type{woProperty}
-		result := resource.AddExtraFields(props, nil)
+		result, err := resource.AddExtraFields(props, nil)
+		if err != nil {
+			t.Fatalf("AddExtraFields() returned error: %v", err)
+		}
 
 		if len(result) != 1 {
 			t.Errorf("Expected 1 property for Wo-suffixed field, got %d", len(result))
@@ -708,7 +1127,10 @@ func TestResourceAddExtraFields(t *testing.T) {
 		regularProp := createTestType("name", "String", withRequired(true))
 
 		props := []*Type{regularProp}
-		result := resource.AddExtraFields(props, nil)
+		result, err := resource.AddExtraFields(props, nil)
+		if err != nil {
+			t.Fatalf("AddExtraFields() returned error: %v", err)
+		}
 
 		if len(result) != 1 {
 			t.Errorf("Expected 1 property for regular field, got %d", len(result))
@@ -732,7 +1154,10 @@ func TestResourceAddExtraFields(t *testing.T) {
 		labelsType := &Type{Name: "labels", Type: "KeyValueLabels"}
 
 		props := []*Type{regularProp, writeOnlyProp, labelsType}
-		result := resource.AddExtraFields(props, nil)
+		result, err := resource.AddExtraFields(props, nil)
+		if err != nil {
+			t.Fatalf("AddExtraFields() returned error: %v", err)
+		}
 
 		// Should have: name + password + passwordWo + passwordWoVersion + labels + terraformLabels + effectiveLabels = 7
 		if len(result) != 7 {
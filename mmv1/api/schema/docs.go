@@ -0,0 +1,78 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// loadDocs parses the non-test Go files of the package in dir, relative to
+// the base directory, and records the doc comments of its struct types and
+// their fields keyed by "dir:Type" and "dir:Type.Field".
+func (g *Generator) loadDocs(dir string) error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, filepath.Join(g.baseDirectory, dir), func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					doc := ts.Doc
+					if doc == nil && len(gd.Specs) == 1 {
+						doc = gd.Doc
+					}
+					g.docs[dir+":"+ts.Name.Name] = commentText(doc)
+					for _, f := range st.Fields.List {
+						text := commentText(f.Doc)
+						if text == "" {
+							text = commentText(f.Comment)
+						}
+						for _, n := range f.Names {
+							g.docs[dir+":"+ts.Name.Name+"."+n.Name] = text
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// commentText joins a comment group into a single paragraph-preserving
+// description.
+func commentText(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	return strings.TrimSpace(cg.Text())
+}
@@ -0,0 +1,300 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema builds JSON Schema documents describing the MMv1 YAML
+// surface from the structs in the api package, so editors can autocomplete
+// and validate product.yaml and resource YAML files.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"gopkg.in/yaml.v3"
+)
+
+const draft = "https://json-schema.org/draft/2020-12/schema"
+
+const modulePath = "github.com/GoogleCloudPlatform/magic-modules/mmv1/"
+
+// Schema is the subset of JSON Schema emitted for MMv1 YAML files.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Id                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"-"`
+	Closed               bool               `json:"-"`
+	Items                *Schema            `json:"items,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// MarshalJSON renders AdditionalProperties as either a schema or `false`
+// for objects that don't accept unknown keys.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type schemaAlias Schema
+	out := struct {
+		*schemaAlias
+		AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+	}{schemaAlias: (*schemaAlias)(s)}
+	if s.AdditionalProperties != nil {
+		out.AdditionalProperties = s.AdditionalProperties
+	} else if s.Closed {
+		out.AdditionalProperties = false
+	}
+	return json.Marshal(out)
+}
+
+// Fields whose values are restricted to a fixed set, keyed by the
+// package-qualified struct name and Go field name.
+var enums = map[string][]string{
+	"api.Type.Type":                 api.PropertyTypes,
+	"api.Type.MinVersion":           product.ORDER,
+	"api.Type.ExactVersion":         product.ORDER,
+	"api.Async.Type":                api.AsyncTypes,
+	"api.Resource.CreateVerb":       api.CreateVerbs,
+	"api.Resource.ReadVerb":         api.ReadVerbs,
+	"api.Resource.UpdateVerb":       api.UpdateVerbs,
+	"api.Resource.DeleteVerb":       api.DeleteVerbs,
+	"api.Resource.MinVersion":       product.ORDER,
+	"product.Version.Name":          product.ORDER,
+	"resource.Examples.MinVersion":  product.ORDER,
+	"resource.IamPolicy.MinVersion": product.ORDER,
+	"resource.Sample.MinVersion":    product.ORDER,
+	"resource.Step.MinVersion":      product.ORDER,
+}
+
+// Generator reflects over api structs to build schemas. Descriptions are
+// read from the Go doc comments of the structs' source files, found under
+// the mmv1 base directory.
+type Generator struct {
+	baseDirectory string
+	// Doc comments keyed by "dir:Type" and "dir:Type.Field".
+	docs       map[string]string
+	loadedDocs map[string]bool
+	defs       map[string]*Schema
+	// Set while building the schema of a struct the loader decodes without
+	// rejecting unknown fields.
+	lenient bool
+}
+
+var unmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+func NewGenerator(baseDirectory string) *Generator {
+	return &Generator{
+		baseDirectory: baseDirectory,
+		docs:          make(map[string]string),
+		loadedDocs:    make(map[string]bool),
+		defs:          make(map[string]*Schema),
+	}
+}
+
+// ProductSchema returns the schema for product.yaml files.
+func (g *Generator) ProductSchema() (*Schema, error) {
+	return g.rootSchema(reflect.TypeOf(api.Product{}), "MMv1 product", "product.schema.json")
+}
+
+// ResourceSchema returns the schema for resource YAML files.
+func (g *Generator) ResourceSchema() (*Schema, error) {
+	return g.rootSchema(reflect.TypeOf(api.Resource{}), "MMv1 resource", "resource.schema.json")
+}
+
+// WriteSchemas writes product.schema.json and resource.schema.json to dir.
+func (g *Generator) WriteSchemas(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for name, build := range map[string]func() (*Schema, error){
+		"product.schema.json":  g.ProductSchema,
+		"resource.schema.json": g.ResourceSchema,
+	} {
+		s, err := build()
+		if err != nil {
+			return err
+		}
+		out, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), append(out, '\n'), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Generator) rootSchema(t reflect.Type, title, id string) (*Schema, error) {
+	g.defs = make(map[string]*Schema)
+	ref, err := g.schemaFor(t)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{
+		Schema: draft,
+		Id:     id,
+		Title:  title,
+		Ref:    ref.Ref,
+		Defs:   g.defs,
+	}, nil
+}
+
+// schemaFor returns the schema for a Go type. Named structs are added to
+// the definitions and referenced, which also handles recursive types such
+// as api.Type.
+func (g *Generator) schemaFor(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		values, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		// yaml.v3 doesn't carry KnownFields through a custom UnmarshalYAML,
+		// so the loader accepts unknown fields anywhere below such a type.
+		lenient := g.lenient || reflect.PointerTo(t).Implements(unmarshalerType)
+		defer func(prev bool) { g.lenient = prev }(g.lenient)
+		g.lenient = lenient
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := defName(t)
+		if lenient {
+			name += ".lenient"
+		}
+		if _, ok := g.defs[name]; !ok {
+			// Reserve the name before recursing so self references terminate.
+			g.defs[name] = nil
+			s, err := g.structSchema(t)
+			if err != nil {
+				return nil, err
+			}
+			if s.Description == "" {
+				s.Description, err = g.doc(t, "")
+				if err != nil {
+					return nil, err
+				}
+			}
+			g.defs[name] = s
+		}
+		return &Schema{Ref: "#/$defs/" + name}, nil
+	default:
+		return nil, fmt.Errorf("unsupported kind %s for type %s", t.Kind(), t)
+	}
+}
+
+func (g *Generator) structSchema(t reflect.Type) (*Schema, error) {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema), Closed: !g.lenient}
+	if err := g.addFields(s, t); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// addFields adds the YAML fields of t to s, flattening inlined structs the
+// same way yaml.v3 does.
+func (g *Generator) addFields(s *Schema, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(opts, "inline") {
+			if err := g.addFields(s, f.Type); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+
+		fs, err := g.schemaFor(f.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t, f.Name, err)
+		}
+		if values, ok := enums[defName(t)+"."+f.Name]; ok {
+			fs.Enum = values
+		}
+		description, err := g.doc(t, f.Name)
+		if err != nil {
+			return err
+		}
+		// JSON Schema 2020-12 allows a description alongside $ref.
+		fs.Description = description
+		s.Properties[name] = fs
+	}
+	return nil
+}
+
+// defName returns the package-qualified name of a struct type, e.g.
+// "resource.Examples".
+func defName(t reflect.Type) string {
+	return t.String()
+}
+
+// doc returns the doc comment for the type t, or for its field when field
+// is set.
+func (g *Generator) doc(t reflect.Type, field string) (string, error) {
+	if !strings.HasPrefix(t.PkgPath(), modulePath) {
+		return "", nil
+	}
+	dir := strings.TrimPrefix(t.PkgPath(), modulePath)
+	if !g.loadedDocs[dir] {
+		if err := g.loadDocs(dir); err != nil {
+			return "", err
+		}
+		g.loadedDocs[dir] = true
+	}
+	key := dir + ":" + t.Name()
+	if field != "" {
+		key += "." + field
+	}
+	return g.docs[key], nil
+}
@@ -0,0 +1,95 @@
+package schema
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func newTestGenerator(t *testing.T) *Generator {
+	_, testFilePath, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("Failed to get current test file path")
+	}
+	// api/schema -> mmv1
+	return NewGenerator(filepath.Dir(filepath.Dir(filepath.Dir(testFilePath))))
+}
+
+func TestResourceSchema(t *testing.T) {
+	s, err := newTestGenerator(t).ResourceSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	typeDef := s.Defs["api.Type"]
+	if typeDef == nil {
+		t.Fatal("expected api.Type definition")
+	}
+	if got := typeDef.Properties["type"].Enum; len(got) == 0 {
+		t.Error("expected enum values for api.Type.Type")
+	}
+	if got := typeDef.Properties["properties"].Items.Ref; got != "#/$defs/api.Type" {
+		t.Errorf("expected nested properties to reference api.Type, got %q", got)
+	}
+	if got := s.Defs["api.Resource"].Properties["create_verb"].Description; !strings.Contains(got, "HTTP verb") {
+		t.Errorf("expected create_verb description from doc comment, got %q", got)
+	}
+	// Inlined structs are flattened into their parent.
+	if _, ok := s.Defs["api.Async.lenient"].Properties["target_occurrences"]; !ok {
+		t.Error("expected inlined PollAsync fields on api.Async")
+	}
+	// Types with a custom UnmarshalYAML, and the types below them, accept
+	// unknown fields like the loader does.
+	if s.Defs["api.Async.lenient"].Closed || s.Defs["api.Timeouts.lenient"].Closed {
+		t.Error("expected api.Async and its nested types to accept unknown fields")
+	}
+	if !s.Defs["api.Timeouts"].Closed {
+		t.Error("expected api.Timeouts outside api.Async to reject unknown fields")
+	}
+}
+
+func TestValidateYAML(t *testing.T) {
+	s, err := newTestGenerator(t).ResourceSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		description string
+		content     string
+		expected    []string
+	}{
+		{
+			description: "valid",
+			content:     "name: Foo\ncreate_verb: PUT\nproperties:\n  - name: bar\n    type: String\n",
+		},
+		{
+			description: "invalid values",
+			content:     "name: Foo\ncreate_verb: GET\nproperties:\n  - name: bar\n    type: Strings\n    bogus: true\n",
+			expected: []string{
+				`test.yaml:2:14: create_verb: value "GET" should be one of [POST PUT PATCH]`,
+				`test.yaml:5:11: properties[0].type: value "Strings" should be one of`,
+				`test.yaml:6:5: properties[0]: unknown field "bogus"`,
+			},
+		},
+		{
+			description: "unknown fields below a custom unmarshaler",
+			content:     "name: Foo\nexamples:\n  - name: foo\n    skip_docs: true\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			es := s.ValidateYAML([]byte(tc.content), "test.yaml")
+			if len(es) != len(tc.expected) {
+				t.Fatalf("expected %d errors, got %v", len(tc.expected), es)
+			}
+			for i, want := range tc.expected {
+				if !strings.HasPrefix(es[i].Error(), want) {
+					t.Errorf("expected error %q, got %q", want, es[i])
+				}
+			}
+		})
+	}
+}
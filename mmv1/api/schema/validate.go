@@ -0,0 +1,123 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateYAML checks a YAML document against a root schema returned by
+// ProductSchema or ResourceSchema. It understands only the subset of JSON
+// Schema that this package emits.
+func (s *Schema) ValidateYAML(content []byte, path string) []error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return []error{&google.YamlError{Path: path, Msg: err.Error()}}
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	v := validator{root: s, path: path}
+	v.validate(s, doc.Content[0], "")
+	return v.errs
+}
+
+type validator struct {
+	root *Schema
+	path string
+	errs []error
+}
+
+func (v *validator) errorf(n *yaml.Node, field, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if field != "" {
+		msg = fmt.Sprintf("%s: %s", field, msg)
+	}
+	v.errs = append(v.errs, &google.YamlError{Path: v.path, Line: n.Line, Column: n.Column, Msg: msg})
+}
+
+func (v *validator) resolve(s *Schema) *Schema {
+	for s.Ref != "" {
+		s = v.root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+	}
+	return s
+}
+
+func (v *validator) validate(s *Schema, n *yaml.Node, field string) {
+	s = v.resolve(s)
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	// An explicit null is equivalent to leaving the field unset.
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return
+	}
+
+	switch s.Type {
+	case "":
+		// Any value is accepted.
+	case "object":
+		if n.Kind != yaml.MappingNode {
+			v.errorf(n, field, "expected a mapping")
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			name := key.Value
+			if field != "" {
+				name = field + "." + key.Value
+			}
+			if ps, ok := s.Properties[key.Value]; ok {
+				v.validate(ps, value, name)
+			} else if s.AdditionalProperties != nil {
+				v.validate(s.AdditionalProperties, value, name)
+			} else if s.Closed {
+				v.errorf(key, field, "unknown field %q", key.Value)
+			}
+		}
+	case "array":
+		if n.Kind != yaml.SequenceNode {
+			v.errorf(n, field, "expected a sequence")
+			return
+		}
+		for i, item := range n.Content {
+			v.validate(s.Items, item, fmt.Sprintf("%s[%d]", field, i))
+		}
+	case "string":
+		if n.Kind != yaml.ScalarNode {
+			v.errorf(n, field, "expected a string")
+		}
+	case "boolean":
+		if n.Kind != yaml.ScalarNode || n.Tag != "!!bool" {
+			v.errorf(n, field, "expected a boolean")
+		}
+	case "integer":
+		if n.Kind != yaml.ScalarNode || n.Tag != "!!int" {
+			v.errorf(n, field, "expected an integer")
+		}
+	case "number":
+		if n.Kind != yaml.ScalarNode || (n.Tag != "!!int" && n.Tag != "!!float") {
+			v.errorf(n, field, "expected a number")
+		}
+	}
+
+	if len(s.Enum) > 0 && n.Kind == yaml.ScalarNode && !slices.Contains(s.Enum, n.Value) {
+		v.errorf(n, field, "value %q should be one of %v", n.Value, s.Enum)
+	}
+}
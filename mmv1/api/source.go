@@ -0,0 +1,137 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SourceFile identifies a YAML input file as it should be reported to users.
+type SourceFile struct {
+	// Path of the file, relative to the base or override directory it was
+	// read from.
+	Path string
+
+	// Override is true when the file was read from the overrides directory.
+	Override bool
+}
+
+// SourcePosition records where in the YAML input an object was declared.
+// It is populated by Compile and carried through Merge, so an object that
+// was changed by an override file points at the override.
+type SourcePosition struct {
+	SourceFile
+
+	// Line and Column are 1-based. A zero Line means the position is
+	// unknown, for example for fields added by AddExtraFields.
+	Line   int
+	Column int
+}
+
+func (p SourcePosition) String() string {
+	if p.Line == 0 {
+		return ""
+	}
+	s := fmt.Sprintf("%s:%d:%d", p.Path, p.Line, p.Column)
+	if p.Override {
+		s += " (override)"
+	}
+	return s
+}
+
+// errorSuffix returns the position formatted for appending to an error
+// message, or an empty string if the position is unknown.
+func (p SourcePosition) errorSuffix() string {
+	if p.Line == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (at %s)", p)
+}
+
+var sourcePositionType = reflect.TypeOf(SourcePosition{})
+
+// recordSourcePositions walks node alongside v and sets the SourcePos field of
+// every struct that has one to the position of its YAML mapping.
+func recordSourcePositions(node *yaml.Node, v reflect.Value, src SourceFile) {
+	if node == nil {
+		return
+	}
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) > 0 {
+			recordSourcePositions(node.Content[0], v, src)
+		}
+		return
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		if f := v.FieldByName("SourcePos"); f.IsValid() && f.Type() == sourcePositionType && f.CanSet() {
+			f.Set(reflect.ValueOf(SourcePosition{SourceFile: src, Line: node.Line, Column: node.Column}))
+		}
+		fields := yamlFields(v.Type())
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if index, ok := fields[node.Content[i].Value]; ok {
+				recordSourcePositions(node.Content[i+1], v.FieldByIndex(index), src)
+			}
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i := 0; i < v.Len() && i < len(node.Content); i++ {
+			recordSourcePositions(node.Content[i], v.Index(i), src)
+		}
+	}
+}
+
+// yamlFields maps the YAML keys of a struct type to field indexes, following
+// the same naming rules as yaml.v3 including inlined structs.
+func yamlFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(opts, "inline") && f.Type.Kind() == reflect.Struct {
+			for k, index := range yamlFields(f.Type) {
+				fields[k] = append([]int{i}, index...)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = []int{i}
+	}
+	return fields
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRecordSourcePositions(t *testing.T) {
+	t.Parallel()
+
+	content := `name: Widget
+properties:
+  - name: settings
+    type: NestedObject
+    properties:
+      - name: size
+        type: Integer
+  - name: tags
+    type: Array
+    item_type:
+      type: String
+`
+	var r Resource
+	if err := yaml.Unmarshal([]byte(content), &r); err != nil {
+		t.Fatal(err)
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &node); err != nil {
+		t.Fatal(err)
+	}
+	src := SourceFile{Path: "products/foo/Widget.yaml"}
+	recordSourcePositions(&node, reflect.ValueOf(&r), src)
+
+	cases := []struct {
+		description string
+		got         SourcePosition
+		line        int
+		column      int
+	}{
+		{"resource", r.SourcePos, 1, 1},
+		{"property", r.Properties[0].SourcePos, 3, 5},
+		{"nested property", r.Properties[0].Properties[0].SourcePos, 6, 9},
+		{"item type", r.Properties[1].ItemType.SourcePos, 11, 7},
+	}
+	for _, tc := range cases {
+		want := SourcePosition{SourceFile: src, Line: tc.line, Column: tc.column}
+		if tc.got != want {
+			t.Errorf("%s: expected %v, got %v", tc.description, want, tc.got)
+		}
+	}
+}
+
+func TestMergeKeepsOverrideSourcePosition(t *testing.T) {
+	t.Parallel()
+
+	base := Resource{
+		SourcePos: SourcePosition{SourceFile: SourceFile{Path: "products/foo/Widget.yaml"}, Line: 1, Column: 1},
+		Properties: []*Type{
+			{Name: "size", SourcePos: SourcePosition{SourceFile: SourceFile{Path: "products/foo/Widget.yaml"}, Line: 3, Column: 5}},
+			{Name: "color", SourcePos: SourcePosition{SourceFile: SourceFile{Path: "products/foo/Widget.yaml"}, Line: 5, Column: 5}},
+		},
+	}
+	overrideFile := SourceFile{Path: "products/foo/Widget.yaml", Override: true}
+	override := Resource{
+		SourcePos: SourcePosition{SourceFile: overrideFile, Line: 1, Column: 1},
+		Properties: []*Type{
+			{Name: "size", Description: "overridden", SourcePos: SourcePosition{SourceFile: overrideFile, Line: 2, Column: 5}},
+		},
+	}
+	Merge(reflect.ValueOf(&base).Elem(), reflect.ValueOf(override), "ga")
+
+	if !base.SourcePos.Override {
+		t.Errorf("expected resource position to come from the override, got %v", base.SourcePos)
+	}
+	if got := base.Properties[0].SourcePos; !got.Override || got.Line != 2 {
+		t.Errorf("expected overridden property position to come from the override, got %v", got)
+	}
+	if got := base.Properties[1].SourcePos; got.Override || got.Line != 5 {
+		t.Errorf("expected untouched property position to come from the base, got %v", got)
+	}
+}
+
+func TestTypeValidateIncludesSourcePosition(t *testing.T) {
+	t.Parallel()
+
+	p := &Type{
+		Name:      "size",
+		Type:      "Bogus",
+		SourcePos: SourcePosition{SourceFile: SourceFile{Path: "products/foo/Widget.yaml"}, Line: 3, Column: 5},
+		ResourceMetadata: &Resource{
+			Name:            "Widget",
+			ProductMetadata: &Product{Name: "Foo"},
+		},
+	}
+	es := p.Validate("Widget")
+	if len(es) != 1 {
+		t.Fatalf("expected 1 error, got %v", es)
+	}
+	want := `property size unknown type "Bogus" in resource Widget (at products/foo/Widget.yaml:3:5)`
+	if got := es[0].Error(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -298,6 +298,17 @@ type Type struct {
 
 	ParentMetadata *Type `yaml:"-"`
 
+	// Where the property was declared in the YAML input. Points at the
+	// override file when the property was changed by one.
+	SourcePos SourcePosition `yaml:"-"`
+
+	// Override directives, only valid for properties in override files.
+	// `$remove: true` deletes the inherited property with the same name, and
+	// `$after: fieldName` moves the property, inherited or new, to directly
+	// after its sibling fieldName. Both are consumed by Merge.
+	OverrideRemove bool   `yaml:"$remove,omitempty"`
+	OverrideAfter  string `yaml:"$after,omitempty"`
+
 	// The prefix used as part of the property expand/flatten function name
 	// flatten{{$.GetPrefix}}{{$.TitlelizeProperty}}
 	Prefix string `yaml:"prefix,omitempty"`
@@ -492,6 +503,9 @@ func (t *Type) SetDefault(r *Resource) {
 	}
 }
 
+// The values allowed for the `type` of a property.
+var PropertyTypes = []string{"Boolean", "Double", "Integer", "String", "Time", "Enum", "ResourceRef", "NestedObject", "Array", "KeyValuePairs", "KeyValueLabels", "KeyValueTerraformLabels", "KeyValueEffectiveLabels", "KeyValueAnnotations", "Map", "Fingerprint"}
+
 func (t *Type) Validate(rName string) (es []error) {
 	// Use Lineage to get the full path (e.g. "parent.child.grandchild") for clearer error messages.
 	fullFieldPath := t.Name
@@ -504,10 +518,18 @@ func (t *Type) Validate(rName string) (es []error) {
 	}
 
 	// Check type is valid. Also allow empty as it's currently used in unit tests.
-	if !slices.Contains([]string{"Boolean", "Double", "Integer", "String", "Time", "Enum", "ResourceRef", "NestedObject", "Array", "KeyValuePairs", "KeyValueLabels", "KeyValueTerraformLabels", "KeyValueEffectiveLabels", "KeyValueAnnotations", "Map", "Fingerprint"}, t.Type) {
+	if !slices.Contains(PropertyTypes, t.Type) {
 		es = append(es, fmt.Errorf("property %s unknown type %q in resource %s", fullFieldPath, t.Type, rName))
 	}
 
+	// Directives that Merge could not apply are left set.
+	if t.OverrideRemove {
+		es = append(es, fmt.Errorf("property %s `$remove` was not applied, it must be in an override file and match an inherited property in resource %s", fullFieldPath, rName))
+	}
+	if t.OverrideAfter != "" {
+		es = append(es, fmt.Errorf("property %s `$after: %s` was not applied, it must be in an override file and match a sibling property in resource %s", fullFieldPath, t.OverrideAfter, rName))
+	}
+
 	if t.Output && t.Required {
 		es = append(es, fmt.Errorf("property %s cannot be output and required at the same time in resource %s.", fullFieldPath, rName))
 	}
@@ -524,7 +546,13 @@ func (t *Type) Validate(rName string) (es []error) {
 		es = append(es, fmt.Errorf("property %s cannot be write_only and sensitive at the same time in resource %s", fullFieldPath, rName))
 	}
 
-	t.validateLabelsField()
+	es = append(es, t.validateLabelsField()...)
+
+	// Nested properties report their own positions, so only errors for this
+	// property are annotated here.
+	es = utils.TransformErrs(func(e error) error {
+		return fmt.Errorf("%w%s", e, t.SourcePos.errorSuffix())
+	}, es)
 
 	switch {
 	case t.IsA("Array"):
@@ -961,6 +989,9 @@ func (t Type) TFType(s string) string {
 	return "schema.TypeString"
 }
 
+// GetFWType returns the plugin framework type name of the field, as used in
+// types.<Name>Type and fwschema.<Name>Attribute. Nested objects are lists,
+// and maps are sets, matching their shape in SDK resources.
 func (t Type) GetFWType() string {
 	switch t.Type {
 	case "Boolean":
@@ -978,8 +1009,11 @@ func (t Type) GetFWType() string {
 	case "ResourceRef":
 		return "String"
 	case "NestedObject":
-		return "Object"
+		return "List"
 	case "Array":
+		if t.IsSet {
+			return "Set"
+		}
 		return "List"
 	case "KeyValuePairs":
 		return "Map"
@@ -992,7 +1026,7 @@ func (t Type) GetFWType() string {
 	case "KeyValueAnnotations":
 		return "Map"
 	case "Map":
-		return "Map"
+		return "Set"
 	case "Fingerprint":
 		return "String"
 	}
@@ -1000,6 +1034,34 @@ func (t Type) GetFWType() string {
 	return "String"
 }
 
+// IsFWBlock reports whether a field is a block in a plugin framework
+// resource. Nested objects, arrays of nested objects and maps are blocks, as
+// they are in SDK resources, unless they are output only, in which case they
+// are computed nested attributes.
+func (t Type) IsFWBlock() bool {
+	if t.IsFWOutput() {
+		return false
+	}
+	switch {
+	case t.IsA("NestedObject"), t.IsA("Map"):
+		return true
+	case t.IsA("Array"):
+		return t.ItemType != nil && t.ItemType.IsA("NestedObject")
+	}
+	return false
+}
+
+// IsFWOutput reports whether a field or any field it's nested in is output
+// only. Nested attributes of a computed attribute must also be computed.
+func (t Type) IsFWOutput() bool {
+	for p := &t; p != nil; p = p.ParentMetadata {
+		if p.Output {
+			return true
+		}
+	}
+	return false
+}
+
 // TODO rewrite: validation
 // // Represents an enum, and store is valid values
 // class Enum < Primitive
@@ -1254,7 +1316,7 @@ func propertyWithAtLeastOneOfPointer(ptr *[]string) func(*Type) {
 	}
 }
 
-func (t *Type) validateLabelsField() {
+func (t *Type) validateLabelsField() (es []error) {
 	productName := t.ResourceMetadata.ProductMetadata.Name
 	resourceName := t.ResourceMetadata.Name
 	lineage := strings.Join(t.Lineage(), ".")
@@ -1277,21 +1339,22 @@ func (t *Type) validateLabelsField() {
 
 			// The "labels" field has type Array, so skip this resource
 			!(productName == "Monitoring" && resourceName == "MetricDescriptor") {
-			log.Fatalf("Please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+			es = append(es, fmt.Errorf("please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName))
 		}
 	} else if t.IsA("KeyValueLabels") {
-		log.Fatalf("Please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+		es = append(es, fmt.Errorf("please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName))
 	}
 
 	if lineage == "annotations" || lineage == "metadata.annotations" {
 		if !t.IsA("KeyValueAnnotations") &&
 			// The "annotations" field has "ouput: true", so skip this eap resource
 			!(productName == "Gkeonprem" && resourceName == "BareMetalAdminClusterEnrollment") {
-			log.Fatalf("Please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+			es = append(es, fmt.Errorf("please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName))
 		}
 	} else if t.IsA("KeyValueAnnotations") {
-		log.Fatalf("Please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+		es = append(es, fmt.Errorf("please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName))
 	}
+	return es
 }
 
 func (t Type) fieldMinVersion() string {
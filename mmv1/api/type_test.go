@@ -567,7 +567,11 @@ func TestProviderOnly(t *testing.T) {
 			},
 		},
 	}
-	labeled.Properties = labeled.AddExtraFields(labeled.PropertiesWithExcluded(), nil)
+	props, err := labeled.AddExtraFields(labeled.PropertiesWithExcluded(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labeled.Properties = props
 	labeled.SetDefault(nil)
 
 	cases := []struct {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"log"
 	"os"
@@ -66,10 +67,14 @@ func main() {
 	}
 
 	var product api.Product
-	api.Compile(*productFlag, &product)
+	if err := api.Compile(*productFlag, &product); err != nil {
+		log.Fatal(err)
+	}
 	if *productOverrideFlag != "" {
 		var override api.Product
-		api.Compile(*productOverrideFlag, &override)
+		if err := api.Compile(*productOverrideFlag, &override); err != nil {
+			log.Fatal(err)
+		}
 		api.Merge(reflect.ValueOf(product), reflect.ValueOf(override), *versionFlag)
 	}
 	if !product.ExistsAtVersionOrLower(*versionFlag) {
@@ -79,20 +84,30 @@ func main() {
 
 	if *resourceFlag != "" {
 		var resource api.Resource
-		api.Compile(*resourceFlag, &resource)
+		if err := api.Compile(*resourceFlag, &resource); err != nil {
+			log.Fatal(err)
+		}
 		if *resourceOverrideFlag != "" {
 			var override api.Resource
-			api.Compile(*resourceOverrideFlag, &override)
+			if err := api.Compile(*resourceOverrideFlag, &override); err != nil {
+				log.Fatal(err)
+			}
 			api.Merge(reflect.ValueOf(resource), reflect.ValueOf(override), *versionFlag)
 		}
 		resource.TargetVersionName = *versionFlag
 		resource.SetDefault(&product)
-		resource.Properties = resource.AddExtraFields(resource.PropertiesWithExcluded(), nil)
+		props, err := resource.AddExtraFields(resource.PropertiesWithExcluded(), nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+		resource.Properties = props
 		resource.SetDefault(&product)
 		product.Objects = []*api.Resource{&resource}
 	}
 
-	product.Validate()
+	if es := product.Validate(); len(es) > 0 {
+		log.Fatal(errors.Join(es...))
+	}
 
 	wd, err := os.Getwd()
 	if err != nil {
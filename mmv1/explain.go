@@ -0,0 +1,117 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+)
+
+// runExplain implements the explain subcommand, which shows how overrides
+// change a resource.
+//
+// Example usage: explain --overrides ../overrides --product pubsub --resource Topic
+func runExplain(args []string) error {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	productName := fs.String("product", "", "product name, its directory under products/")
+	resourceName := fs.String("resource", "", "resource name")
+	version := fs.String("version", "ga", "version to load the resource at")
+	baseDirectory := fs.String("base", "", "optional directory containing mmv1 products/. Empty value defaults to GetCwd().")
+	overrideDirectory := fs.String("overrides", "", "directory containing yaml overrides")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s explain --overrides DIR --product PRODUCT --resource RESOURCE\n\n", os.Args[0])
+		fmt.Fprintln(fs.Output(), "Prints the fields of a resource that its overrides add, remove or change.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *productName == "" || *resourceName == "" || *overrideDirectory == "" {
+		fs.Usage()
+		return fmt.Errorf("--product, --resource and --overrides are required")
+	}
+	if *baseDirectory == "" {
+		var err error
+		if *baseDirectory, err = os.Getwd(); err != nil {
+			return err
+		}
+	}
+
+	productPath := path.Join("products", *productName)
+	base, err := loadExplainedResource(*version, *baseDirectory, "", productPath, *resourceName)
+	if err != nil {
+		return err
+	}
+	merged, err := loadExplainedResource(*version, *baseDirectory, *overrideDirectory, productPath, *resourceName)
+	if err != nil {
+		return err
+	}
+	if base == nil && merged == nil {
+		return fmt.Errorf("resource %q not found in %s", *resourceName, productPath)
+	}
+
+	writeExplanation(os.Stdout, base, merged)
+	return nil
+}
+
+// loadExplainedResource loads a product and returns the named resource, or
+// nil if neither exists at the given directories.
+func loadExplainedResource(version, baseDirectory, overrideDirectory, productPath, resourceName string) (*api.Resource, error) {
+	ofs, err := google.NewOverlayFS(overrideDirectory, baseDirectory)
+	if err != nil {
+		return nil, err
+	}
+	l := loader.NewLoader(loader.Config{Version: version, BaseDirectory: baseDirectory, OverrideDirectory: overrideDirectory, Sysfs: ofs})
+	if !loader.Exists(baseDirectory, productPath, "product.yaml") && (overrideDirectory == "" || !loader.Exists(overrideDirectory, productPath, "product.yaml")) {
+		return nil, nil
+	}
+	p, err := l.LoadProduct(productPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range p.Objects {
+		if strings.EqualFold(r.Name, resourceName) {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func writeExplanation(w io.Writer, base, merged *api.Resource) {
+	switch {
+	case base == nil:
+		fmt.Fprintf(w, "%s is added by overrides\n", merged.Name)
+		return
+	case merged == nil:
+		fmt.Fprintf(w, "%s is removed by overrides\n", base.Name)
+		return
+	}
+
+	diffs := api.DiffResources(base, merged)
+	if len(diffs) == 0 {
+		fmt.Fprintf(w, "%s is not changed by overrides\n", base.Name)
+		return
+	}
+	fmt.Fprintf(w, "%s: %d fields changed by overrides\n", base.Name, len(diffs))
+	for _, d := range diffs {
+		fmt.Fprintln(w, d)
+	}
+}
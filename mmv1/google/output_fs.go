@@ -0,0 +1,229 @@
+package google
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OutputFS is the output counterpart of ReadDirReadFileFS: the destination
+// generated files are written to. Generated files are read back through it
+// to post-process them, e.g. to add headers or rewrite import paths.
+//
+// Names are host paths, as they would be passed to the os package.
+type OutputFS interface {
+	MkdirAll(path string, perm fs.FileMode) error
+	Stat(name string) (fs.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+}
+
+// DirOutputFS writes generated files directly to disk.
+type DirOutputFS struct{}
+
+func (DirOutputFS) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (DirOutputFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (DirOutputFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (DirOutputFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+// MemoryOutputFS keeps generated files in memory. The collected files can be
+// inspected directly or written out as a tar or zip archive.
+type MemoryOutputFS struct {
+	mu    sync.Mutex
+	files map[string]*memoryFile
+}
+
+type memoryFile struct {
+	name    string
+	data    []byte
+	perm    fs.FileMode
+	modTime time.Time
+}
+
+func NewMemoryOutputFS() *MemoryOutputFS {
+	return &MemoryOutputFS{files: make(map[string]*memoryFile)}
+}
+
+// MkdirAll is a no-op, as directories are implied by the files in them.
+func (m *MemoryOutputFS) MkdirAll(path string, perm fs.FileMode) error {
+	return nil
+}
+
+func (m *MemoryOutputFS) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.files[filepath.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return f, nil
+}
+
+func (m *MemoryOutputFS) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.files[filepath.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return bytes.Clone(f.data), nil
+}
+
+func (m *MemoryOutputFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	m.files[name] = &memoryFile{
+		name:    filepath.Base(name),
+		data:    bytes.Clone(data),
+		perm:    perm,
+		modTime: time.Now(),
+	}
+	return nil
+}
+
+// Files returns the names of all files written, sorted.
+func (m *MemoryOutputFS) Files() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WriteArchive writes the files under root to an archive at path. The format
+// is chosen from the extension: .zip, .tar, or .tar.gz/.tgz.
+func (m *MemoryOutputFS) WriteArchive(path, root string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case strings.HasSuffix(path, ".zip"):
+		err = m.WriteZip(f, root)
+	case strings.HasSuffix(path, ".tar"):
+		err = m.WriteTar(f, root)
+	case strings.HasSuffix(path, ".tar.gz"), strings.HasSuffix(path, ".tgz"):
+		gw := gzip.NewWriter(f)
+		err = m.WriteTar(gw, root)
+		if closeErr := gw.Close(); err == nil {
+			err = closeErr
+		}
+	default:
+		err = fmt.Errorf("unsupported archive format for %s, expected .zip, .tar, .tar.gz or .tgz", path)
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// WriteTar writes the files under root to w as a tar archive, named relative
+// to root.
+func (m *MemoryOutputFS) WriteTar(w io.Writer, root string) error {
+	tw := tar.NewWriter(w)
+	err := m.walk(root, func(name string, f *memoryFile) error {
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    int64(f.perm.Perm()),
+			Size:    int64(len(f.data)),
+			ModTime: f.modTime,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(f.data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// WriteZip writes the files under root to w as a zip archive, named relative
+// to root.
+func (m *MemoryOutputFS) WriteZip(w io.Writer, root string) error {
+	zw := zip.NewWriter(w)
+	err := m.walk(root, func(name string, f *memoryFile) error {
+		hdr := &zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: f.modTime,
+		}
+		hdr.SetMode(f.perm)
+		fw, err := zw.CreateHeader(hdr)
+		if err != nil {
+			return err
+		}
+		_, err = fw.Write(f.data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// walk calls fn for each file in path order, with its slash-separated name
+// relative to root. Files outside of root are an error.
+func (m *MemoryOutputFS) walk(root string, fn func(name string, f *memoryFile) error) error {
+	for _, path := range m.Files() {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("%s is outside of %s", path, root)
+		}
+		m.mu.Lock()
+		f := m.files[path]
+		m.mu.Unlock()
+		if err := fn(filepath.ToSlash(rel), f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// memoryFile implements fs.FileInfo so that MemoryOutputFS.Stat can return it.
+func (f *memoryFile) Name() string       { return f.name }
+func (f *memoryFile) Size() int64        { return int64(len(f.data)) }
+func (f *memoryFile) Mode() fs.FileMode  { return f.perm }
+func (f *memoryFile) ModTime() time.Time { return f.modTime }
+func (f *memoryFile) IsDir() bool        { return false }
+func (f *memoryFile) Sys() any           { return nil }
+
+// Verifying interface implementations
+var _ OutputFS = DirOutputFS{}
+var _ OutputFS = (*MemoryOutputFS)(nil)
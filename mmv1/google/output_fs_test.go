@@ -0,0 +1,134 @@
+package google
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestMemoryOutputFS(t *testing.T) *MemoryOutputFS {
+	m := NewMemoryOutputFS()
+	for name, content := range map[string]string{
+		"out/main.go":            "package main\n",
+		"out/scripts/build.sh":   "#!/bin/sh\n",
+		"out/google/a/../b.go":   "package google\n",
+		"out/google/b.go":        "package google // overwritten\n",
+		"out/google/nested/c.md": "# c\n",
+	} {
+		perm := fs.FileMode(0644)
+		if filepath.Ext(name) == ".sh" {
+			perm = 0755
+		}
+		if err := m.WriteFile(filepath.FromSlash(name), []byte(content), perm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return m
+}
+
+func TestMemoryOutputFS(t *testing.T) {
+	m := newTestMemoryOutputFS(t)
+
+	want := []string{
+		filepath.FromSlash("out/google/b.go"),
+		filepath.FromSlash("out/google/nested/c.md"),
+		filepath.FromSlash("out/main.go"),
+		filepath.FromSlash("out/scripts/build.sh"),
+	}
+	if diff := cmp.Diff(want, m.Files()); diff != "" {
+		t.Errorf("Files() mismatch (-want +got):\n%s", diff)
+	}
+
+	b, err := m.ReadFile(filepath.FromSlash("out/google/b.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), "package google // overwritten\n"; got != want {
+		t.Errorf("ReadFile() = %q, want %q", got, want)
+	}
+
+	info, err := m.Stat(filepath.FromSlash("out/scripts/build.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Name() != "build.sh" || info.Mode() != 0755 || info.Size() != 10 {
+		t.Errorf("Stat() = %s %s %d, want build.sh -rwxr-xr-x 10", info.Name(), info.Mode(), info.Size())
+	}
+
+	if _, err := m.Stat("out/missing.go"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat() of a missing file returned %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestMemoryOutputFSArchives(t *testing.T) {
+	m := newTestMemoryOutputFS(t)
+	want := map[string]string{
+		"google/b.go":        "package google // overwritten\n",
+		"google/nested/c.md": "# c\n",
+		"main.go":            "package main\n",
+		"scripts/build.sh":   "#!/bin/sh\n",
+	}
+
+	var tarBuf bytes.Buffer
+	if err := m.WriteTar(&tarBuf, "out"); err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	tr := tar.NewReader(&tarBuf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[hdr.Name] = string(b)
+		if hdr.Name == "scripts/build.sh" && hdr.Mode != 0755 {
+			t.Errorf("tar mode of %s = %o, want 755", hdr.Name, hdr.Mode)
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("WriteTar() mismatch (-want +got):\n%s", diff)
+	}
+
+	var zipBuf bytes.Buffer
+	if err := m.WriteZip(&zipBuf, "out"); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(zipBuf.Bytes()), int64(zipBuf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[f.Name] = string(b)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("WriteZip() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := m.WriteTar(io.Discard, "out/google"); err == nil {
+		t.Errorf("WriteTar() with files outside of the root returned no error")
+	}
+}
@@ -15,7 +15,10 @@ package google
 
 import (
 	"bytes"
-	"log"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
 
 	"gopkg.in/yaml.v3"
 )
@@ -23,12 +26,80 @@ import (
 // A helper class to validate contents coming from YAML files.
 type YamlValidator struct{}
 
-func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) {
+// YamlError describes a single problem found while decoding a YAML file.
+// Line and Column are 1-based and are zero when yaml.v3 did not report a
+// position for the problem.
+type YamlError struct {
+	Path   string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *YamlError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.Path, e.Msg)
+	}
+	if e.Column == 0 {
+		return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.Path, e.Line, e.Column, e.Msg)
+}
+
+// yaml.v3 reports positions as a "line N: " prefix on its error messages.
+var yamlLineRegexp = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// Parse decodes content into obj, rejecting unknown fields. Every problem
+// reported by the decoder is returned as a *YamlError, joined together
+// with errors.Join when there is more than one.
+func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) error {
 	// Create a new decoder to enable strict validation with KnownFields(true)
 	decoder := yaml.NewDecoder(bytes.NewReader(content))
 	decoder.KnownFields(true)
 
-	if err := decoder.Decode(obj); err != nil {
-		log.Fatalf("Cannot unmarshal data from file %s: %v", yamlPath, err)
+	err := decoder.Decode(obj)
+	if err == nil {
+		return nil
+	}
+
+	var msgs []string
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		msgs = typeErr.Errors
+	} else {
+		msgs = []string{err.Error()}
+	}
+
+	// Best effort: a document that failed to decode into obj may still
+	// parse as a node tree, which lets us recover the column of the node.
+	var root yaml.Node
+	if yaml.Unmarshal(content, &root) != nil {
+		root = yaml.Node{}
+	}
+
+	var es []error
+	for _, msg := range msgs {
+		ye := &YamlError{Path: yamlPath, Msg: msg}
+		if m := yamlLineRegexp.FindStringSubmatch(msg); m != nil {
+			ye.Line, _ = strconv.Atoi(m[1])
+			ye.Msg = m[2]
+			ye.Column = columnForLine(&root, ye.Line)
+		}
+		es = append(es, ye)
+	}
+	return errors.Join(es...)
+}
+
+// columnForLine returns the column of the first node that starts on the given
+// line, or 0 if no such node exists.
+func columnForLine(n *yaml.Node, line int) int {
+	if n.Line == line {
+		return n.Column
+	}
+	for _, c := range n.Content {
+		if col := columnForLine(c, line); col != 0 {
+			return col
+		}
 	}
+	return 0
 }
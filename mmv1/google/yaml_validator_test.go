@@ -0,0 +1,80 @@
+package google
+
+import (
+	"errors"
+	"testing"
+)
+
+type yamlValidatorTestObj struct {
+	Name  string
+	Count int
+	Child struct {
+		Enabled bool
+	}
+}
+
+func TestYamlValidatorParse(t *testing.T) {
+	cases := []struct {
+		description string
+		content     string
+		expected    []YamlError
+	}{
+		{
+			description: "valid",
+			content:     "name: foo\ncount: 1\n",
+		},
+		{
+			description: "unknown field",
+			content:     "name: foo\nchild:\n  enabled: true\n  missing: 1\n",
+			expected: []YamlError{
+				{Path: "test.yaml", Line: 4, Column: 3, Msg: "field missing not found in type struct { Enabled bool }"},
+			},
+		},
+		{
+			description: "multiple errors are all reported",
+			content:     "name: foo\ncount: abc\nunknown: true\n",
+			expected: []YamlError{
+				{Path: "test.yaml", Line: 2, Column: 1, Msg: "cannot unmarshal !!str `abc` into int"},
+				{Path: "test.yaml", Line: 3, Column: 1, Msg: "field unknown not found in type google.yamlValidatorTestObj"},
+			},
+		},
+		{
+			description: "syntax error",
+			content:     "name: foo\n  count: 1\n",
+			expected: []YamlError{
+				{Path: "test.yaml", Line: 2, Msg: "mapping values are not allowed in this context"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			v := YamlValidator{}
+			err := v.Parse([]byte(tc.content), &yamlValidatorTestObj{}, "test.yaml")
+			if len(tc.expected) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var got []*YamlError
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, e := range joined.Unwrap() {
+					var ye *YamlError
+					if errors.As(e, &ye) {
+						got = append(got, ye)
+					}
+				}
+			}
+			if len(got) != len(tc.expected) {
+				t.Fatalf("expected %d errors, got %v", len(tc.expected), err)
+			}
+			for i, want := range tc.expected {
+				if *got[i] != want {
+					t.Errorf("error %d: expected %#v, got %#v", i, want, *got[i])
+				}
+			}
+		})
+	}
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
-	"github.com/golang/glog"
 	"golang.org/x/exp/slices"
 )
 
@@ -22,8 +21,11 @@ type Loader struct {
 	overrideDirectory string
 	compilerTarget    string
 	Products          map[string]*api.Product
-	version           string
-	sysfs             google.ReadDirReadFileFS
+	// Products that were skipped because they don't exist at the version,
+	// sorted.
+	ProductsNotInVersion []string
+	version              string
+	sysfs                google.ReadDirReadFileFS
 }
 
 type Config struct {
@@ -63,7 +65,10 @@ func NewLoader(config Config) *Loader {
 	return l
 }
 
-func (l *Loader) LoadProducts() {
+// LoadProducts loads every product under the base and override directories.
+// Failures are aggregated across all products and resources so that every
+// broken file is reported in a single run.
+func (l *Loader) LoadProducts() error {
 	if l.version == "" {
 		log.Printf("No version specified, assuming ga")
 		l.version = "ga"
@@ -100,10 +105,12 @@ func (l *Loader) LoadProducts() {
 		}
 	}
 
-	l.Products = l.batchLoadProducts(allProductFiles)
+	products, err := l.batchLoadProducts(allProductFiles)
+	l.Products = products
+	return err
 }
 
-func (l *Loader) batchLoadProducts(productNames []string) map[string]*api.Product {
+func (l *Loader) batchLoadProducts(productNames []string) (map[string]*api.Product, error) {
 	products := make(map[string]*api.Product)
 
 	// Create result type for clarity
@@ -138,26 +145,27 @@ func (l *Loader) batchLoadProducts(productNames []string) map[string]*api.Produc
 	close(productChan)
 
 	// Collect results as they complete
-	loadFailureCount := 0
+	var es []error
 	for result := range productChan {
 		if result.err != nil {
 			// Check if the error is the specific "version not found" error
 			var versionErr *ErrProductVersionNotFound
 			if errors.As(result.err, &versionErr) {
+				l.ProductsNotInVersion = append(l.ProductsNotInVersion, result.name)
 				continue
 			}
 
-			loadFailureCount++
-			log.Printf("Error loading %s: %v", result.name, result.err)
+			es = append(es, fmt.Errorf("error loading %s:\n%w", result.name, result.err))
 			continue
 		}
 		products[result.name] = result.product
 	}
-	if loadFailureCount > 0 {
-		log.Fatalf("Failed to load %d products", loadFailureCount)
+	slices.Sort(l.ProductsNotInVersion)
+	if len(es) > 0 {
+		return products, fmt.Errorf("failed to load %d products:\n%w", len(es), errors.Join(es...))
 	}
 
-	return products
+	return products, nil
 }
 
 // Load compiles a product with all its resources from the given path and optional overrides
@@ -183,15 +191,16 @@ func (l *Loader) LoadProduct(productName string) (*api.Product, error) {
 	// Compile the product configuration
 	if overrideProductExists {
 		if baseProductExists {
-			api.Compile(baseProductPath, p)
 			overrideApiProduct := &api.Product{}
-			api.Compile(productOverridePath, overrideApiProduct)
+			if err := errors.Join(l.compile(baseProductPath, false, p), l.compile(productOverridePath, true, overrideApiProduct)); err != nil {
+				return nil, err
+			}
 			api.Merge(reflect.ValueOf(p).Elem(), reflect.ValueOf(*overrideApiProduct), l.version)
-		} else {
-			api.Compile(productOverridePath, p)
+		} else if err := l.compile(productOverridePath, true, p); err != nil {
+			return nil, err
 		}
-	} else {
-		api.Compile(baseProductPath, p)
+	} else if err := l.compile(baseProductPath, false, p); err != nil {
+		return nil, err
 	}
 
 	// Check if product exists at the requested l.Version
@@ -199,25 +208,33 @@ func (l *Loader) LoadProduct(productName string) (*api.Product, error) {
 		return nil, &ErrProductVersionNotFound{ProductName: productName, Version: l.version}
 	}
 
+	// Resources are loaded even if the product is invalid so that errors in
+	// both are reported together.
+	es := p.Validate()
+
 	// Compile all resources
 	p.PackagePath = productName
 	resources, err := l.loadResources(p)
 	if err != nil {
-		return nil, err
+		es = append(es, err)
+	}
+	if len(es) > 0 {
+		return nil, errors.Join(es...)
 	}
 
 	p.Version = p.VersionObjOrClosest(l.version)
 
 	p.Objects = resources
 	p.SetCompiler(l.compilerTarget)
-	p.Validate()
 
 	return p, nil
 }
 
-// loadResources loads all resources for a product
+// loadResources loads all resources for a product. Errors from individual
+// resources are joined so that every broken resource file is reported.
 func (l *Loader) loadResources(product *api.Product) ([]*api.Resource, error) {
 	var resources []*api.Resource = make([]*api.Resource, 0)
+	var es []error
 
 	// Get base resource files
 	resourceFiles, err := filepath.Glob(filepath.Join(l.baseDirectory, product.PackagePath, "*"))
@@ -243,7 +260,11 @@ func (l *Loader) loadResources(product *api.Product) ([]*api.Resource, error) {
 			}
 		}
 
-		resource := l.loadResource(product, resourceYamlPath, "")
+		resource, err := l.loadResource(product, resourceYamlPath, "")
+		if err != nil {
+			es = append(es, err)
+			continue
+		}
 		resources = append(resources, resource)
 	}
 
@@ -251,9 +272,12 @@ func (l *Loader) loadResources(product *api.Product) ([]*api.Resource, error) {
 	if l.overrideDirectory != "" {
 		resources, err = l.reconcileOverrideResources(product, resources)
 		if err != nil {
-			return nil, err
+			es = append(es, err)
 		}
 	}
+	if len(es) > 0 {
+		return nil, errors.Join(es...)
+	}
 	// Sort resources by name for consistent output
 	slices.SortFunc(resources, func(a, b *api.Resource) int {
 		return strings.Compare(a.Name, b.Name)
@@ -269,22 +293,27 @@ func (l *Loader) reconcileOverrideResources(product *api.Product, resources []*a
 		return nil, fmt.Errorf("cannot get override files: %v", err)
 	}
 
+	var es []error
 	for _, overrideYamlPath := range overrideFiles {
 		if filepath.Base(overrideYamlPath) == "product.yaml" || filepath.Ext(overrideYamlPath) != ".yaml" {
 			continue
 		}
 
 		baseResourcePath := filepath.Join(l.baseDirectory, product.PackagePath, filepath.Base(overrideYamlPath))
-		resource := l.loadResource(product, baseResourcePath, overrideYamlPath)
+		resource, err := l.loadResource(product, baseResourcePath, overrideYamlPath)
+		if err != nil {
+			es = append(es, err)
+			continue
+		}
 		resources = append(resources, resource)
 	}
 
-	return resources, nil
+	return resources, errors.Join(es...)
 }
 
 // loadResource loads a single resource with optional override
 // baseResourcePath and overrideResourcePath are expected to be absolute paths.
-func (l *Loader) loadResource(product *api.Product, baseResourcePath string, overrideResourcePath string) *api.Resource {
+func (l *Loader) loadResource(product *api.Product, baseResourcePath string, overrideResourcePath string) (*api.Resource, error) {
 	resource := &api.Resource{}
 
 	// Check if base resource exists
@@ -301,17 +330,20 @@ func (l *Loader) loadResource(product *api.Product, baseResourcePath string, ove
 	if overrideResourcePath != "" {
 		if baseResourceExists {
 			// Merge base and override
-			api.Compile(baseResourcePath, resource)
 			overrideResource := &api.Resource{}
-			api.Compile(overrideResourcePath, overrideResource)
+			if err := errors.Join(l.compile(baseResourcePath, false, resource), l.compile(overrideResourcePath, true, overrideResource)); err != nil {
+				return nil, err
+			}
 			api.Merge(reflect.ValueOf(resource).Elem(), reflect.ValueOf(*overrideResource), l.version)
-		} else {
+		} else if err := l.compile(overrideResourcePath, true, resource); err != nil {
 			// Override only
-			api.Compile(overrideResourcePath, resource)
+			return nil, err
 		}
 	} else {
 		// Base only
-		api.Compile(baseResourcePath, resource)
+		if err := l.compile(baseResourcePath, false, resource); err != nil {
+			return nil, err
+		}
 		resource.SourceYamlFile = baseRelPath
 	}
 
@@ -319,15 +351,35 @@ func (l *Loader) loadResource(product *api.Product, baseResourcePath string, ove
 	resource.TargetVersionName = l.version
 	// SetDefault before AddExtraFields to ensure relevant metadata is available on existing fields
 	resource.SetDefault(product)
-	resource.TestSampleSetUp(l.sysfs)
 
+	var es []error
+	if err := resource.TestSampleSetUp(l.sysfs); err != nil {
+		es = append(es, fmt.Errorf("%s: %w", resource.SourceYamlFile, err))
+	}
 	for _, e := range resource.Examples {
 		if err := e.LoadHCLText(l.sysfs); err != nil {
-			glog.Exit(err)
+			es = append(es, fmt.Errorf("%s: %w", resource.SourceYamlFile, err))
 		}
 	}
+	if len(es) > 0 {
+		return nil, errors.Join(es...)
+	}
 
-	return resource
+	return resource, nil
+}
+
+// compile decodes yamlPath into obj, recording source positions relative to
+// the base or override directory the file was read from.
+func (l *Loader) compile(yamlPath string, override bool, obj interface{}) error {
+	root := l.baseDirectory
+	if override {
+		root = l.overrideDirectory
+	}
+	relPath, err := filepath.Rel(root, yamlPath)
+	if err != nil {
+		relPath = yamlPath
+	}
+	return api.CompileSource(yamlPath, api.SourceFile{Path: relPath, Override: override}, obj)
 }
 
 func (l *Loader) AddExtraFields() error {
@@ -335,22 +387,30 @@ func (l *Loader) AddExtraFields() error {
 		return errors.New("products have not been loaded into memory")
 	}
 
+	var es []error
 	for _, product := range l.Products {
 		for _, resource := range product.Objects {
-			resource.Properties = resource.AddExtraFields(resource.PropertiesWithExcluded(), nil)
+			props, err := resource.AddExtraFields(resource.PropertiesWithExcluded(), nil)
+			if err != nil {
+				es = append(es, fmt.Errorf("%s: %w", resource.SourceYamlFile, err))
+			}
+			resource.Properties = props
 			// SetDefault after AddExtraFields to ensure relevant metadata is available for the newly generated fields
 			resource.SetDefault(product)
 		}
 	}
 
-	return nil
+	return errors.Join(es...)
 }
 
-func (l *Loader) Validate() {
+// Validate validates every loaded resource and returns the validation errors
+// of all resources joined together.
+func (l *Loader) Validate() error {
 	if l.Products == nil {
-		log.Fatalln("products have not been loaded into memory")
+		return errors.New("products have not been loaded into memory")
 	}
 
+	var allErrs []error
 	for _, product := range l.Products {
 		for _, resource := range product.Objects {
 			es := resource.Validate()
@@ -358,8 +418,9 @@ func (l *Loader) Validate() {
 				es = utils.TransformErrs(func(e error) error {
 					return fmt.Errorf("%s%s%s: %w", utils.ColorRed, resource.SourceYamlFile, utils.ColorReset, e)
 				}, es)
-				log.Fatalf("%v", errors.Join(es...))
+				allErrs = append(allErrs, es...)
 			}
 		}
 	}
+	return errors.Join(allErrs...)
 }
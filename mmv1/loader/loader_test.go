@@ -0,0 +1,63 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+func TestLoadProductJoinsProductAndResourceErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	productDir := filepath.Join(dir, "products", "widgets")
+	if err := os.MkdirAll(productDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		// Missing scopes.
+		"product.yaml": `
+name: 'Widgets'
+versions:
+  - name: 'ga'
+    base_url: 'https://widgets.googleapis.com/v1/'
+`,
+		// The sample step config does not exist.
+		"Widget.yaml": `
+name: 'Widget'
+base_url: 'projects/{{project}}/widgets'
+samples:
+  - name: 'widget_basic'
+    primary_resource_id: 'example'
+    steps:
+      - name: 'widget_basic'
+        config_path: 'templates/missing.tf.tmpl'
+properties:
+  - name: 'name'
+    type: String
+`,
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(productDir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sysfs, err := google.NewOverlayFS("", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := NewLoader(Config{Version: "ga", BaseDirectory: dir, Sysfs: sysfs})
+	_, err = l.LoadProduct("products/widgets")
+	if err == nil {
+		t.Fatal("LoadProduct() returned no error")
+	}
+	for _, want := range []string{"missing `scopes`", "missing.tf.tmpl"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("LoadProduct() error %q does not contain %q", err, want)
+		}
+	}
+}
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io/fs"
 	"log"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +18,7 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/schema"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/openapi_generate"
@@ -33,9 +39,14 @@ var baseDirectoryFlag = flag.String("base", "", "optional directory containing m
 
 var overrideDirectoryFlag = flag.String("overrides", "", "optional directory containing yaml overrides")
 
-var productFlag = flag.String("product", "", "optional product name. If specified, the resources under the specific product will be generated. Otherwise, resources under all products will be generated.")
+// Example usage: --product compute/*Firewall*,networksecurity
+var productFlag = stringListFlag("product", "optional product names or product/resource glob patterns, repeated or comma-separated. If specified, only matching resources will be generated. Otherwise, resources under all products will be generated.")
 
-var resourceFlag = flag.String("resource", "", "optional resource name. Limits generation to the specified resource within a particular product.")
+// Example usage: --resource Firewall --resource FirewallPolicy*
+var resourceFlag = stringListFlag("resource", "optional resource name glob patterns, repeated or comma-separated. Limits generation to matching resources within the selected products.")
+
+// Example usage: --exclude compute/Instance*,gkehub
+var excludeFlag = stringListFlag("exclude", "optional product names or product/resource glob patterns, repeated or comma-separated, that are never generated.")
 
 var doNotGenerateCode = flag.Bool("no-code", false, "do not generate code")
 
@@ -43,16 +54,70 @@ var doNotGenerateDocs = flag.Bool("no-docs", false, "do not generate docs")
 
 var providerFlag = flag.String("provider", "", "optional provider name. If specified, a non-default provider will be used.")
 
-var openapiGenerate = flag.Bool("openapi-generate", false, "Generate MMv1 YAML from openapi directory (Experimental)")
+var openapiGenerate = flag.Bool("openapi-generate", false, "Generate MMv1 YAML from openapi directory (Experimental). See the openapi-generate subcommand for more options.")
+
+var openapiMergeFlag = flag.Bool("openapi-merge", false, "with --openapi-generate, merge new API fields into existing resource YAML files instead of overwriting them, keeping hand edits")
+
+var openapiFormatFlag = flag.String("openapi-format", "", "optional input format for --openapi-generate, openapi or discovery. Defaults to discovery for files ending in .discovery.json and openapi otherwise.")
+
+var cacheFlag = flag.Bool("cache", false, "read and write the incremental generation cache, skipping resources whose inputs and outputs are unchanged since the previous run. Ignored when the CI environment variable is set.")
+
+var cacheDirFlag = flag.String("cache-dir", "", "optional directory for the --cache incremental generation cache. Defaults to a magic-modules directory in the user cache directory.")
+
+var forceFlag = flag.Bool("force", false, "with --cache, regenerate every resource even if the cache says it is unchanged, and update the cache")
+
+var dryRunFlag = flag.Bool("dry-run", false, "generate in memory without writing to --output. Prints a JSON manifest of the files that would be added, changed, deleted or left unchanged, followed by a unified diff against the existing output. Files are only reported as deleted when every resource, its code and its docs are generated.")
+
+var dryRunDiffFlag = flag.String("dry-run-diff", "", "optional file to write the --dry-run unified diff to instead of standard output")
+
+// Example usage: --archive /tmp/terraform-provider-google.tar.gz
+var archiveFlag = flag.String("archive", "", "optional .zip, .tar, .tar.gz or .tgz file to write generated files to instead of --output. Paths in the archive are relative to --output.")
+
+// Example usage: --report /tmp/generation-report.json
+var reportFlag = flag.String("report", "", "optional file to write a JSON report of the products and resources generated or skipped, the files written, the templates used and the time taken per resource")
+
+// Example usage: --emit-schema .vscode/schemas
+var emitSchemaFlag = flag.String("emit-schema", "", "optional directory to write JSON Schemas for product.yaml and resource YAML files to. No code is generated when set.")
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		if err := runExplain(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "openapi-generate" {
+		if err := runOpenapiGenerate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	// Handle all flags in main. Other functions must not access flag values directly.
 	flag.Parse()
 
 	if *openapiGenerate {
 		parser := openapi_generate.NewOpenapiParser("openapi_generate/openapi", "products")
-		parser.Run()
+		parser.Format = *openapiFormatFlag
+		parser.Merge = *openapiMergeFlag
+		if err := parser.Run(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *emitSchemaFlag != "" {
+		baseDirectory := *baseDirectoryFlag
+		if baseDirectory == "" {
+			var err error
+			if baseDirectory, err = os.Getwd(); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if err := schema.NewGenerator(baseDirectory).WriteSchemas(*emitSchemaFlag); err != nil {
+			log.Fatalf("Error writing schemas: %v", err)
+		}
+		log.Printf("Wrote schemas to %q", *emitSchemaFlag)
 		return
 	}
 
@@ -61,10 +126,162 @@ func main() {
 		return
 	}
 
-	GenerateProducts(*productFlag, *resourceFlag, *providerFlag, *versionFlag, *outputPathFlag, *baseDirectoryFlag, *overrideDirectoryFlag, !*doNotGenerateCode, !*doNotGenerateDocs)
+	if (*dryRunFlag || *archiveFlag != "" || *cacheFlag) && *providerFlag != "" {
+		log.Fatalf("--dry-run, --archive and --cache are only supported by the default provider")
+	}
+	if *dryRunFlag && *archiveFlag != "" {
+		log.Fatalf("--dry-run and --archive can't be used together")
+	}
+
+	filter, err := provider.NewResourceFilter(*productFlag, *resourceFlag, *excludeFlag)
+	if err != nil {
+		log.Fatalf("Error parsing resource filters: %v", err)
+	}
+
+	var output google.OutputFS
+	var dryRun *provider.DryRun
+	var archive *google.MemoryOutputFS
+	if *dryRunFlag {
+		dryRun = provider.NewDryRun()
+		// Files that weren't generated are only stale if everything was.
+		dryRun.ReportDeleted = filter.MatchesAll() && !*doNotGenerateCode && !*doNotGenerateDocs
+		output = dryRun
+	} else if *archiveFlag != "" {
+		archive = google.NewMemoryOutputFS()
+		output = archive
+	}
+
+	var cache *provider.GenerationCache
+	// Unless generating to a directory, every file must be generated, so
+	// cached resources can't be skipped. CI builds always start clean.
+	if *cacheFlag && os.Getenv("CI") != "" {
+		log.Printf("CI is set, not using the generation cache")
+	} else if *cacheFlag && output == nil {
+		cachePath, err := generationCachePath(*cacheDirFlag, *outputPathFlag, *providerFlag, *versionFlag)
+		if err != nil {
+			log.Fatalf("Error locating the generation cache: %v", err)
+		}
+		if cache, err = provider.LoadGenerationCache(cachePath, *forceFlag); err != nil {
+			log.Fatalf("Error loading the generation cache: %v", err)
+		}
+	}
+
+	var report *provider.GenerationReport
+	if *reportFlag != "" {
+		report = provider.NewGenerationReport(*outputPathFlag)
+	}
+
+	GenerateProducts(filter, *providerFlag, *versionFlag, *outputPathFlag, *baseDirectoryFlag, *overrideDirectoryFlag, !*doNotGenerateCode, !*doNotGenerateDocs, cache, output, report)
+
+	if report != nil {
+		if err := report.Write(*reportFlag); err != nil {
+			log.Fatalf("Error writing report: %v", err)
+		}
+		log.Printf("Wrote generation report to %q", *reportFlag)
+	}
+
+	if dryRun != nil {
+		if err := reportDryRun(dryRun, *outputPathFlag, *dryRunDiffFlag); err != nil {
+			log.Fatalf("Error reporting dry run: %v", err)
+		}
+	}
+	if archive != nil {
+		if err := archive.WriteArchive(*archiveFlag, *outputPathFlag); err != nil {
+			log.Fatalf("Error writing archive: %v", err)
+		}
+		log.Printf("Wrote %d files to %q", len(archive.Files()), *archiveFlag)
+	}
+
+	if cache != nil {
+		reportCacheDecisions(cache)
+		if err := cache.Save(); err != nil {
+			log.Printf("Error saving the generation cache: %v", err)
+		}
+	}
+}
+
+// stringList is a flag that may be repeated, with each value split on commas.
+type stringList []string
+
+func stringListFlag(name, usage string) *stringList {
+	var s stringList
+	flag.Var(&s, name, usage)
+	return &s
+}
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*s = append(*s, v)
+		}
+	}
+	return nil
+}
+
+// generationCachePath returns the cache file for a given output, provider and
+// version, so that separate builds don't invalidate each other.
+func generationCachePath(cacheDir, outputPath, providerName, version string) (string, error) {
+	if cacheDir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		cacheDir = filepath.Join(userCacheDir, "magic-modules")
+	}
+	absOutputPath, err := filepath.Abs(outputPath)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{absOutputPath, providerName, version}, "\x00")))
+	return filepath.Join(cacheDir, hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+func reportCacheDecisions(cache *provider.GenerationCache) {
+	skipped := 0
+	for _, d := range cache.Decisions() {
+		if !d.Regenerated {
+			skipped++
+			continue
+		}
+		log.Printf("Regenerated %s: %s", d.Resource, d.Reason)
+	}
+	log.Printf("Skipped %d unchanged resources", skipped)
 }
 
-func GenerateProducts(product, resource, providerName, version, outputPath, baseDirectory, overrideDirectory string, generateCode, generateDocs bool) {
+// reportDryRun prints the manifest of a dry run to standard output, followed
+// by the diff unless diffPath is set.
+func reportDryRun(dryRun *provider.DryRun, outputPath, diffPath string) error {
+	manifest, err := dryRun.Manifest(outputPath)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(struct {
+		Files []provider.ManifestEntry `json:"files"`
+	}{manifest}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+
+	if diffPath == "" {
+		return dryRun.WriteDiff(os.Stdout, outputPath)
+	}
+	f, err := os.Create(diffPath)
+	if err != nil {
+		return err
+	}
+	if err := dryRun.WriteDiff(f, outputPath); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func GenerateProducts(filter *provider.ResourceFilter, providerName, version, outputPath, baseDirectory, overrideDirectory string, generateCode, generateDocs bool, cache *provider.GenerationCache, output google.OutputFS, report *provider.GenerationReport) {
 	if version == "" {
 		log.Printf("No version specified, assuming ga")
 		version = "ga"
@@ -83,6 +300,10 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 	log.Printf("Generating MM output to %q", outputPath)
 	log.Printf("Building %q version", version)
 	log.Printf("Building %q provider", providerName)
+	log.Printf("Selecting %s", filter)
+	if report != nil {
+		report.Version, report.Provider, report.StartTime = version, providerName, startTime
+	}
 
 	ofs, err := google.NewOverlayFS(overrideDirectory, baseDirectory)
 	if err != nil {
@@ -90,24 +311,23 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 	}
 
 	loader := loader.NewLoader(loader.Config{Version: version, BaseDirectory: baseDirectory, OverrideDirectory: overrideDirectory, Sysfs: ofs, CompilerTarget: providerName})
-	loader.LoadProducts()
-	loader.AddExtraFields()
-	loader.Validate()
+	if err := loader.LoadProducts(); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := loader.AddExtraFields(); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := loader.Validate(); err != nil {
+		log.Fatalf("%v", err)
+	}
 	loadedProducts := loader.Products
-
-	var productsToGenerate []string
-	if product == "" {
-		for _, p := range loadedProducts {
-			productsToGenerate = append(productsToGenerate, p.PackagePath)
-		}
-	} else {
-		var productToGenerate = fmt.Sprintf("products/%s", product)
-		productsToGenerate = []string{productToGenerate}
+	for _, p := range loader.ProductsNotInVersion {
+		report.SkipProduct(path.Base(p), provider.SkipNotInVersion)
 	}
 
 	for _, productApi := range loadedProducts {
 		wg.Add(1)
-		go GenerateProduct(version, providerName, productApi, outputPath, startTime, ofs, productsToGenerate, resource, generateCode, generateDocs)
+		go GenerateProduct(version, providerName, productApi, outputPath, startTime, ofs, filter, generateCode, generateDocs, cache, output, report)
 	}
 	wg.Wait()
 
@@ -121,7 +341,7 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 
 	// In order to only copy/compile files once per provider this must be called outside
 	// of the products loop. Create an MMv1 provider with an arbitrary product (the first loaded).
-	providerToGenerate := newProvider(providerName, version, productsForVersion[0], startTime, ofs)
+	providerToGenerate := newProvider(providerName, version, productsForVersion[0], startTime, ofs, nil, output, report)
 	providerToGenerate.CopyCommonFiles(outputPath, generateCode, generateDocs)
 
 	if generateCode {
@@ -132,21 +352,27 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 // GenerateProduct generates code and documentation for a product
 // This now uses the CompileProduct method to separate compilation from generation
 func GenerateProduct(version, providerName string, productApi *api.Product, outputPath string,
-	startTime time.Time, fsys fs.FS, productsToGenerate []string, resourceToGenerate string,
-	generateCode, generateDocs bool) {
+	startTime time.Time, fsys fs.FS, filter *provider.ResourceFilter,
+	generateCode, generateDocs bool, cache *provider.GenerationCache, output google.OutputFS, report *provider.GenerationReport) {
 	defer wg.Done()
 
-	if !slices.Contains(productsToGenerate, productApi.PackagePath) {
+	if !filter.MatchesProduct(productApi) {
 		log.Printf("%s not specified, skipping generation", productApi.PackagePath)
+		report.SkipProduct(provider.ProductName(productApi), provider.SkipFiltered)
 		return
 	}
 
 	log.Printf("%s: Generating files", productApi.PackagePath)
-	providerToGenerate := newProvider(providerName, version, productApi, startTime, fsys)
-	providerToGenerate.Generate(outputPath, resourceToGenerate, generateCode, generateDocs)
+	productStartTime := time.Now()
+	providerToGenerate := newProvider(providerName, version, productApi, startTime, fsys, cache, output, report)
+	providerToGenerate.Generate(outputPath, filter, generateCode, generateDocs)
+	report.GeneratedProduct(productApi, time.Since(productStartTime))
 }
 
-func newProvider(providerName, version string, productApi *api.Product, startTime time.Time, fsys fs.FS) provider.Provider {
+// newProvider builds the provider for providerName. The generation cache,
+// output FS and resource-level reporting are only supported by the default
+// Terraform provider and may be nil.
+func newProvider(providerName, version string, productApi *api.Product, startTime time.Time, fsys fs.FS, cache *provider.GenerationCache, output google.OutputFS, report *provider.GenerationReport) provider.Provider {
 	switch providerName {
 	case "tgc":
 		return provider.NewTerraformGoogleConversion(productApi, version, startTime, fsys)
@@ -157,6 +383,10 @@ func newProvider(providerName, version string, productApi *api.Product, startTim
 	case "oics":
 		return provider.NewTerraformOiCS(productApi, version, startTime, fsys)
 	default:
-		return provider.NewTerraform(productApi, version, startTime, fsys)
+		t := provider.NewTerraform(productApi, version, startTime, fsys)
+		t.Cache = cache
+		t.Output = output
+		t.Report = report
+		return t
 	}
 }
@@ -0,0 +1,59 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/openapi_generate"
+)
+
+// runOpenapiGenerate implements the openapi-generate subcommand, which
+// generates MMv1 YAML from OpenAPI specs and Discovery documents.
+//
+// Example usage: openapi-generate --resource Widget --dry-run specs/widgets_v1.yaml specs/widgets_v1beta.yaml
+func runOpenapiGenerate(args []string) error {
+	fs := flag.NewFlagSet("openapi-generate", flag.ExitOnError)
+	input := fs.String("input", "openapi_generate/openapi", "directory of specs to read when no spec files are given")
+	output := fs.String("output", "products", "directory to write products to")
+	examples := fs.String("examples", "templates/terraform/examples", "directory to write example config templates to")
+	format := fs.String("format", "", "optional input format, openapi or discovery. Defaults to discovery for files ending in .discovery.json and openapi otherwise.")
+	merge := fs.Bool("merge", false, "merge new API fields into existing resource YAML files instead of overwriting them, keeping hand edits")
+	dryRun := fs.Bool("dry-run", false, "print the files that would be added, changed or left unchanged instead of writing them")
+	var resources stringList
+	fs.Var(&resources, "resource", "optional resource name glob patterns, repeated or comma-separated. If specified, only matching resources are generated.")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s openapi-generate [flags] [SPEC_FILE...]\n\n", os.Args[0])
+		fmt.Fprintln(fs.Output(), "Generates products from OpenAPI specs or Discovery documents. Specs of different versions of an API, such as widgets_v1.yaml and widgets_v1beta.yaml, are read together.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	examplesPath, err := filepath.Abs(*examples)
+	if err != nil {
+		return err
+	}
+
+	parser := openapi_generate.NewOpenapiParser(*input, *output)
+	parser.Files = fs.Args()
+	parser.Examples = examplesPath
+	parser.Format = *format
+	parser.Merge = *merge
+	parser.DryRun = *dryRun
+	parser.Resources = resources
+	return parser.Run()
+}
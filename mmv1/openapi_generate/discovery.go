@@ -0,0 +1,357 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Input formats understood by the parser.
+const (
+	FormatOpenAPI   = "openapi"
+	FormatDiscovery = "discovery"
+)
+
+// discoveryExtension marks Google API Discovery documents when the input
+// format isn't set explicitly, e.g. pubsub_v1.discovery.json.
+const discoveryExtension = ".discovery.json"
+
+// inputFormat returns the format to parse filePath with: format if set,
+// otherwise the one implied by the file extension.
+func inputFormat(filePath, format string) string {
+	if format != "" {
+		return format
+	}
+	if strings.HasSuffix(filePath, discoveryExtension) {
+		return FormatDiscovery
+	}
+	return FormatOpenAPI
+}
+
+// discoveryDoc is the subset of a Google API Discovery document
+// (https://developers.google.com/discovery/v1/reference/apis) used to build
+// resources.
+type discoveryDoc struct {
+	Name        string                        `json:"name"`
+	Version     string                        `json:"version"`
+	Title       string                        `json:"title"`
+	Description string                        `json:"description"`
+	RootUrl     string                        `json:"rootUrl"`
+	ServicePath string                        `json:"servicePath"`
+	Schemas     map[string]*discoverySchema   `json:"schemas"`
+	Resources   map[string]*discoveryResource `json:"resources"`
+}
+
+type discoveryResource struct {
+	Methods   map[string]*discoveryMethod   `json:"methods"`
+	Resources map[string]*discoveryResource `json:"resources"`
+}
+
+type discoveryMethod struct {
+	Id          string                      `json:"id"`
+	Path        string                      `json:"path"`
+	FlatPath    string                      `json:"flatPath"`
+	HttpMethod  string                      `json:"httpMethod"`
+	Description string                      `json:"description"`
+	Parameters  map[string]*discoverySchema `json:"parameters"`
+	Request     *discoverySchema            `json:"request"`
+	Response    *discoverySchema            `json:"response"`
+}
+
+// discoverySchema is used for schemas, their properties and method
+// parameters, which share most fields.
+type discoverySchema struct {
+	Id                   string                      `json:"id"`
+	Ref                  string                      `json:"$ref"`
+	Type                 string                      `json:"type"`
+	Format               string                      `json:"format"`
+	Description          string                      `json:"description"`
+	Enum                 []string                    `json:"enum"`
+	ReadOnly             bool                        `json:"readOnly"`
+	Required             bool                        `json:"required"`
+	Location             string                      `json:"location"`
+	Pattern              string                      `json:"pattern"`
+	Properties           map[string]*discoverySchema `json:"properties"`
+	Items                *discoverySchema            `json:"items"`
+	AdditionalProperties *discoverySchema            `json:"additionalProperties"`
+}
+
+// loadDiscovery reads a Discovery document and converts it to an OpenAPI
+// document, so resources are built the same way for both formats.
+func loadDiscovery(filePath string) (*openapi3.T, error) {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return parseDiscovery(b)
+}
+
+func parseDiscovery(data []byte) (*openapi3.T, error) {
+	var d discoveryDoc
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("error parsing Discovery document: %w", err)
+	}
+	if d.RootUrl == "" || d.Version == "" {
+		return nil, fmt.Errorf("error parsing Discovery document: rootUrl and version are required")
+	}
+	return d.toOpenAPI()
+}
+
+// toOpenAPI converts the document. Schemas become components, and the
+// standard create, patch, delete, get and list methods of each collection
+// become operations with the CreateX, UpdateX, DeleteX, GetX and ListX IDs
+// that findResources expects, where X is the schema the collection holds.
+// Methods returning an Operation are marked as long-running.
+func (d discoveryDoc) toOpenAPI() (*openapi3.T, error) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info: &openapi3.Info{
+			Title:       d.Title,
+			Description: d.Description,
+			Version:     d.Version,
+		},
+		Servers:    openapi3.Servers{{URL: strings.TrimSuffix(d.RootUrl, "/")}},
+		Paths:      openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: make(openapi3.Schemas)},
+	}
+
+	// Create every component first so references, including cyclic ones,
+	// can point at them.
+	for name := range d.Schemas {
+		doc.Components.Schemas[name] = &openapi3.SchemaRef{Value: &openapi3.Schema{}}
+	}
+	var es []error
+	for _, name := range slices.Sorted(maps.Keys(d.Schemas)) {
+		ref, err := d.schema(doc, d.Schemas[name])
+		if err != nil {
+			es = append(es, fmt.Errorf("schema %s: %w", name, err))
+			continue
+		}
+		*doc.Components.Schemas[name].Value = *ref.Value
+	}
+
+	var walk func(resources map[string]*discoveryResource)
+	walk = func(resources map[string]*discoveryResource) {
+		for _, k := range slices.Sorted(maps.Keys(resources)) {
+			if err := d.addOperations(doc, resources[k]); err != nil {
+				es = append(es, err)
+			}
+			walk(resources[k].Resources)
+		}
+	}
+	walk(d.Resources)
+	if len(es) > 0 {
+		return nil, fmt.Errorf("error converting Discovery document: %w", errors.Join(es...))
+	}
+	return doc, nil
+}
+
+// discoveryOperations maps standard Discovery method names to the verbs of
+// the operation IDs findResources looks for. IAM methods are found by their
+// path, and keep their Discovery ID. update methods are PUT requests, and are
+// only used for collections without a patch method.
+var discoveryOperations = map[string]string{
+	"create":       "Create",
+	"insert":       "Create",
+	"patch":        "Update",
+	"update":       "Update",
+	"delete":       "Delete",
+	"get":          "Get",
+	"list":         "List",
+	"getIamPolicy": "",
+	"setIamPolicy": "",
+}
+
+func (d discoveryDoc) addOperations(doc *openapi3.T, resource *discoveryResource) error {
+	name := collectionSchema(resource)
+	if name == "" {
+		return nil
+	}
+	if _, ok := doc.Components.Schemas[name]; !ok {
+		return fmt.Errorf("unknown schema %q", name)
+	}
+
+	for _, methodName := range slices.Sorted(maps.Keys(discoveryOperations)) {
+		m, ok := resource.Methods[methodName]
+		if !ok {
+			continue
+		}
+		if _, ok := resource.Methods["patch"]; ok && methodName == "update" {
+			continue
+		}
+		op := &openapi3.Operation{
+			OperationID: discoveryOperations[methodName] + name,
+			Description: m.Description,
+			Responses:   openapi3.NewResponses(),
+		}
+		if discoveryOperations[methodName] == "" {
+			op.OperationID = m.Id
+		}
+		params, err := d.parameters(doc, m)
+		if err != nil {
+			return fmt.Errorf("method %s: %w", m.Id, err)
+		}
+		op.Parameters = params
+		if m.Request != nil {
+			request, err := d.schema(doc, m.Request)
+			if err != nil {
+				return fmt.Errorf("method %s request: %w", m.Id, err)
+			}
+			op.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchemaRef(request)}
+		}
+		if m.Response != nil {
+			response, err := d.schema(doc, m.Response)
+			if err != nil {
+				return fmt.Errorf("method %s response: %w", m.Id, err)
+			}
+			op.Responses.Set("200", &openapi3.ResponseRef{Value: openapi3.NewResponse().WithJSONSchemaRef(response)})
+			if m.Response.Ref == "Operation" {
+				op.Extensions = map[string]any{"x-google-lro": true}
+			}
+		}
+
+		path := "/" + strings.TrimPrefix(m.FlatPath, "/")
+		if m.FlatPath == "" {
+			path = "/" + strings.TrimPrefix(m.Path, "/")
+		}
+		item := doc.Paths.Value(path)
+		if item == nil {
+			item = &openapi3.PathItem{}
+			doc.Paths.Set(path, item)
+		}
+		item.SetOperation(m.HttpMethod, op)
+	}
+	return nil
+}
+
+// collectionSchema returns the schema held by a collection: the request
+// body of its create method, or of its patch or update method for singletons.
+func collectionSchema(resource *discoveryResource) string {
+	for _, methodName := range []string{"create", "insert", "patch", "update"} {
+		if m, ok := resource.Methods[methodName]; ok && m.Request != nil {
+			return m.Request.Ref
+		}
+	}
+	return ""
+}
+
+var flatPathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// parameters returns the path parameters of the method's flat path, such as
+// projectsId and locationsId, followed by its query parameters.
+func (d discoveryDoc) parameters(doc *openapi3.T, m *discoveryMethod) (openapi3.Parameters, error) {
+	var params openapi3.Parameters
+	for _, match := range flatPathParam.FindAllStringSubmatch(m.FlatPath, -1) {
+		params = append(params, &openapi3.ParameterRef{Value: &openapi3.Parameter{
+			Name:     match[1],
+			In:       openapi3.ParameterInPath,
+			Required: true,
+			Schema:   openapi3.NewStringSchema().NewRef(),
+		}})
+	}
+	for _, k := range slices.Sorted(maps.Keys(m.Parameters)) {
+		p := m.Parameters[k]
+		if p.Location != "query" {
+			continue
+		}
+		schema, err := d.schema(doc, p)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", k, err)
+		}
+		params = append(params, &openapi3.ParameterRef{Value: &openapi3.Parameter{
+			Name:        k,
+			In:          openapi3.ParameterInQuery,
+			Description: p.Description,
+			Required:    p.Required,
+			Schema:      schema,
+		}})
+	}
+	return params, nil
+}
+
+// schema converts a Discovery schema. References point at the shared
+// component, so each schema is only converted once. It returns an error if a
+// reference names a schema the document doesn't define.
+func (d discoveryDoc) schema(doc *openapi3.T, s *discoverySchema) (*openapi3.SchemaRef, error) {
+	if s.Ref != "" {
+		component, ok := doc.Components.Schemas[s.Ref]
+		if !ok {
+			return nil, fmt.Errorf("unknown schema %q", s.Ref)
+		}
+		return &openapi3.SchemaRef{Ref: "#/components/schemas/" + s.Ref, Value: component.Value}, nil
+	}
+
+	schema := &openapi3.Schema{
+		Description: s.Description,
+		Format:      s.Format,
+		ReadOnly:    s.ReadOnly,
+		Pattern:     s.Pattern,
+	}
+	switch s.Type {
+	case "", "any":
+		// Untyped values are treated as objects with unknown fields.
+		schema.Type = &openapi3.Types{"object"}
+	default:
+		schema.Type = &openapi3.Types{s.Type}
+	}
+	for _, e := range s.Enum {
+		schema.Enum = append(schema.Enum, e)
+	}
+	if s.Items != nil {
+		items, err := d.schema(doc, s.Items)
+		if err != nil {
+			return nil, err
+		}
+		schema.Items = items
+	}
+	if s.AdditionalProperties != nil {
+		values, err := d.schema(doc, s.AdditionalProperties)
+		if err != nil {
+			return nil, err
+		}
+		schema.AdditionalProperties = openapi3.AdditionalProperties{Schema: values}
+	}
+	if len(s.Properties) > 0 {
+		schema.Properties = make(openapi3.Schemas)
+	}
+	for _, k := range slices.Sorted(maps.Keys(s.Properties)) {
+		p := s.Properties[k]
+		ref, err := d.schema(doc, p)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", k, err)
+		}
+		// Discovery has no required or immutable annotations for fields,
+		// they are only stated in the description following AIP-203.
+		if strings.HasPrefix(p.Description, "Required.") {
+			schema.Required = append(schema.Required, k)
+		}
+		if strings.HasPrefix(p.Description, "Immutable.") {
+			ref.Extensions = map[string]any{"x-google-immutable": true}
+		}
+		if strings.HasPrefix(p.Description, "Identifier.") {
+			ref.Extensions = map[string]any{"x-google-identifier": true}
+		}
+		schema.Properties[k] = ref
+	}
+	return schema.NewRef(), nil
+}
@@ -0,0 +1,211 @@
+package openapi_generate
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+//go:embed test_data/widgets_v1.discovery.json
+var testDiscovery []byte
+
+func TestInputFormat(t *testing.T) {
+	cases := []struct {
+		filePath, format, expected string
+	}{
+		{"specs/widgets_v1.discovery.json", "", FormatDiscovery},
+		{"specs/widgets_v1.yaml", "", FormatOpenAPI},
+		{"specs/widgets_v1.json", "", FormatOpenAPI},
+		{"specs/widgets_v1.json", FormatDiscovery, FormatDiscovery},
+	}
+	for _, tc := range cases {
+		if got := inputFormat(tc.filePath, tc.format); got != tc.expected {
+			t.Errorf("inputFormat(%q, %q) = %q, expected %q", tc.filePath, tc.format, got, tc.expected)
+		}
+	}
+}
+
+func TestDiscoveryResources(t *testing.T) {
+	doc, err := parseDiscovery(testDiscovery)
+	if err != nil {
+		t.Fatalf("Could not parse Discovery document %s", err)
+	}
+
+	res := findResources(doc)
+	if len(res) != 3 {
+		t.Fatalf("Expected 3 resources, found: %d", len(res))
+	}
+	widget := res["Widget"]
+	if widget.create == nil || widget.update == nil || widget.delete == nil {
+		t.Fatalf("Expected Widget to have create, update and delete methods, found %+v", widget)
+	}
+	if settings := res["Settings"]; settings.create != nil || settings.update == nil {
+		t.Errorf("Expected Settings to be a singleton, found %+v", settings)
+	}
+
+	resource := buildResource("Widget", widget, doc)
+	if got, want := resource.BaseUrl, "projects/{{project}}/locations/{{location}}/widgets"; got != want {
+		t.Errorf("Expected BaseUrl %q, found %q", want, got)
+	}
+	if got, want := resource.CreateUrl, "projects/{{project}}/locations/{{location}}/widgets?widgetId={{widget_id}}"; got != want {
+		t.Errorf("Expected CreateUrl %q, found %q", want, got)
+	}
+	if resource.UpdateVerb != "PATCH" {
+		t.Errorf("Expected UpdateVerb PATCH, found %q", resource.UpdateVerb)
+	}
+	if got, want := resource.Async.Actions, []string{"create", "update", "delete"}; !slices.Equal(got, want) {
+		t.Errorf("Expected async actions %v, found %v", want, got)
+	}
+
+	var params []string
+	for _, p := range resource.Parameters {
+		params = append(params, p.Name)
+	}
+	if want := []string{"location", "widgetId"}; !slices.Equal(params, want) {
+		t.Errorf("Expected parameters %v, found %v", want, params)
+	}
+
+	props := make(map[string]*api.Type)
+	for _, p := range resource.Properties {
+		props[p.Name] = p
+	}
+	for name, want := range map[string]string{
+		"name":        "String",
+		"displayName": "String",
+		"size":        "Integer",
+		"state":       "Enum",
+		"labels":      "KeyValueLabels",
+		"tags":        "Array",
+		"config":      "NestedObject",
+	} {
+		if props[name] == nil || props[name].Type != want {
+			t.Errorf("Expected property %s of type %s, found %+v", name, want, props[name])
+		}
+	}
+	if !props["name"].Output || !props["state"].Output {
+		t.Error("Expected identifier and read-only properties to be output")
+	}
+	if !props["displayName"].Required {
+		t.Error("Expected displayName to be required")
+	}
+	if !props["size"].Immutable {
+		t.Error("Expected size to be immutable")
+	}
+	if len(props["config"].Properties) != 2 {
+		t.Errorf("Expected config to have 2 properties, found %d", len(props["config"].Properties))
+	}
+}
+
+func TestDiscoveryMissingSchema(t *testing.T) {
+	doc := `{
+  "name": "widgets",
+  "version": "v1",
+  "title": "Widgets API",
+  "rootUrl": "https://widgets.googleapis.com/",
+  "schemas": {
+    "Widget": {
+      "id": "Widget",
+      "type": "object",
+      "properties": {
+        "spec": {"$ref": "WidgetSpec"}
+      }
+    }
+  }
+}`
+	_, err := parseDiscovery([]byte(doc))
+	if err == nil || !strings.Contains(err.Error(), `unknown schema "WidgetSpec"`) {
+		t.Errorf("Expected an error naming the missing schema, found %v", err)
+	}
+}
+
+func TestDiscoveryUpdateMethod(t *testing.T) {
+	doc := `{
+  "name": "widgets",
+  "version": "v1",
+  "title": "Widgets API",
+  "rootUrl": "https://widgets.googleapis.com/",
+  "resources": {
+    "projects": {
+      "resources": {
+        "settings": {
+          "methods": {
+            "update": {
+              "id": "widgets.projects.settings.update",
+              "path": "v1/{+name}",
+              "flatPath": "v1/projects/{projectsId}/settings",
+              "httpMethod": "PUT",
+              "parameters": {
+                "name": {"type": "string", "location": "path", "required": true}
+              },
+              "request": {"$ref": "Settings"},
+              "response": {"$ref": "Settings"}
+            }
+          }
+        }
+      }
+    }
+  },
+  "schemas": {
+    "Settings": {
+      "id": "Settings",
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "enabled": {"type": "boolean"}
+      }
+    }
+  }
+}`
+	parsed, err := parseDiscovery([]byte(doc))
+	if err != nil {
+		t.Fatalf("Could not parse Discovery document %s", err)
+	}
+
+	settings := findResources(parsed)["Settings"]
+	if settings == nil || settings.update == nil {
+		t.Fatalf("Expected Settings to have an update method, found %+v", settings)
+	}
+	resource := buildSingleton("Settings", settings, parsed)
+	if resource.CreateVerb != "PUT" || resource.UpdateVerb != "PUT" {
+		t.Errorf("Expected CreateVerb and UpdateVerb PUT, found %q and %q", resource.CreateVerb, resource.UpdateVerb)
+	}
+	if got, want := resource.CreateUrl, "projects/{{project}}/settings"; got != want {
+		t.Errorf("Expected CreateUrl %q, found %q", want, got)
+	}
+}
+
+func TestDiscoveryWriteYaml(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "widgets_v1.discovery.json")
+	if err := os.WriteFile(input, testDiscovery, 0644); err != nil {
+		t.Fatal(err)
+	}
+	output := t.TempDir()
+	parser := Parser{Output: output}
+	if err := parser.WriteYaml(input); err != nil {
+		t.Fatal(err)
+	}
+
+	product := &api.Product{}
+	if err := api.Compile(filepath.Join(output, "widgets", "product.yaml"), product); err != nil {
+		t.Fatalf("Could not compile product.yaml: %s", err)
+	}
+	if got, want := product.Versions[0].BaseUrl, "https://widgets.googleapis.com/v1/"; got != want {
+		t.Errorf("Expected base URL %q, found %q", want, got)
+	}
+	for _, name := range []string{"Widget", "Gadget", "Settings"} {
+		resource := &api.Resource{}
+		if err := api.Compile(filepath.Join(output, "widgets", name+".yaml"), resource); err != nil {
+			t.Errorf("Could not compile %s.yaml: %s", name, err)
+		}
+		// Examples are only generated with an examples directory to write
+		// their config templates to
+		if len(resource.Examples) > 0 {
+			t.Errorf("Expected %s.yaml to have no examples, found %d", name, len(resource.Examples))
+		}
+	}
+}
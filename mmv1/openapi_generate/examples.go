@@ -0,0 +1,147 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// buildExample returns a minimal example of a resource, which sets its
+// required fields to placeholder values, and the Terraform config template
+// it points at. Name-like fields are set from example vars so that tests
+// get unique names.
+func buildExample(productName string, resource api.Resource) (*r.Examples, string) {
+	example := &r.Examples{
+		Name:              fmt.Sprintf("%s_%s_basic", google.Underscore(productName), google.Underscore(resource.Name)),
+		PrimaryResourceId: "example",
+		Vars:              make(map[string]string),
+		MinVersion:        resource.MinVersion,
+	}
+
+	var config strings.Builder
+	fmt.Fprintf(&config, "{{/* autogen_status: %s\n", resource.AutogenStatus)
+	config.WriteString("Generated from the required fields of the API, with placeholder values. Review and refine before merging. */ -}}\n")
+	fmt.Fprintf(&config, "resource \"google_%s_%s\" \"{{$.PrimaryResourceId}}\" {\n", google.Underscore(productName), google.Underscore(resource.Name))
+	if resource.MinVersion != "" && resource.MinVersion != "ga" {
+		config.WriteString("  provider = google-beta\n")
+	}
+	for _, p := range resource.Parameters {
+		if p.Required && p.MinVersion == "" {
+			writeExampleField(&config, example, resource.Name, p, "  ")
+		}
+	}
+	for _, p := range resource.Properties {
+		if p.Required && !p.Output && p.MinVersion == "" {
+			writeExampleField(&config, example, resource.Name, p, "  ")
+		}
+	}
+	config.WriteString("}\n")
+
+	return example, config.String()
+}
+
+// writeExampleField writes a field of an example config, with nested
+// objects as blocks holding their own required fields.
+func writeExampleField(config *strings.Builder, example *r.Examples, resourceName string, p *api.Type, indent string) {
+	name := google.Underscore(p.Name)
+	switch {
+	case p.Type == "NestedObject" || (p.Type == "Array" && p.ItemType != nil && p.ItemType.Type == "NestedObject"):
+		properties := p.Properties
+		if p.Type == "Array" {
+			properties = p.ItemType.Properties
+		}
+		fmt.Fprintf(config, "%s%s {\n", indent, name)
+		for _, nested := range properties {
+			if nested.Required && !nested.Output && nested.MinVersion == "" {
+				writeExampleField(config, example, resourceName, nested, indent+"  ")
+			}
+		}
+		fmt.Fprintf(config, "%s}\n", indent)
+	case isNameField(p, resourceName):
+		example.Vars[name] = fmt.Sprintf("example-%s", strings.ReplaceAll(google.Underscore(resourceName), "_", "-"))
+		fmt.Fprintf(config, "%s%s = \"{{index $.Vars %q}}\"\n", indent, name, name)
+	default:
+		fmt.Fprintf(config, "%s%s = %s\n", indent, name, exampleValue(p, indent))
+	}
+}
+
+// isNameField reports whether a field names the resource, and so must be
+// unique between tests.
+func isNameField(p *api.Type, resourceName string) bool {
+	if p.Type != "String" {
+		return false
+	}
+	name := google.Underscore(p.Name)
+	return name == "name" || name == google.Underscore(resourceName)+"_id" || name == google.Underscore(resourceName)+"_name"
+}
+
+// exampleValue returns a placeholder HCL value for a field, indented to be
+// set at indent.
+func exampleValue(p *api.Type, indent string) string {
+	switch p.Type {
+	case "Integer":
+		return "1"
+	case "Double":
+		return "1.5"
+	case "Boolean":
+		return "true"
+	case "Enum":
+		if len(p.EnumValues) > 0 {
+			return fmt.Sprintf("%q", p.EnumValues[0])
+		}
+	case "Time":
+		return `"2030-01-01T00:00:00Z"`
+	case "Array":
+		if p.ItemType != nil {
+			return fmt.Sprintf("[%s]", exampleValue(p.ItemType, indent))
+		}
+		return "[]"
+	case "KeyValuePairs", "KeyValueLabels", "KeyValueAnnotations", "Map":
+		return fmt.Sprintf("{\n%s  key = \"value\"\n%s}", indent, indent)
+	}
+
+	switch {
+	case p.IsJsonField():
+		return "jsonencode({})"
+	case p.Name == "location" || p.Name == "region":
+		return `"us-central1"`
+	case p.Name == "zone":
+		return `"us-central1-a"`
+	case p.Validation.Function == "verify.ValidateDuration()":
+		return `"3600s"`
+	case p.Validation.Function == "verify.ValidateBase64String":
+		return `base64encode("example")`
+	}
+	return `"example"`
+}
+
+// writeExampleConfig writes the config template of an example. With Merge,
+// a template that already exists is left as it is, as it has likely been
+// refined by hand.
+func (parser Parser) writeExampleConfig(example *r.Examples, config string) {
+	configPath := filepath.Join(parser.Examples, fmt.Sprintf("%s.tf.tmpl", example.Name))
+	if _, err := os.Stat(configPath); parser.Merge && err == nil {
+		log.Printf("Keeping existing example %s", configPath)
+		return
+	}
+	parser.writeFile(configPath, []byte(config))
+}
@@ -0,0 +1,114 @@
+package openapi_generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBuildExample(t *testing.T) {
+	doc, err := parseDiscovery(testDiscovery)
+	if err != nil {
+		t.Fatalf("Could not parse Discovery document %s", err)
+	}
+	resource := buildResource("Widget", findResources(doc)["Widget"], doc)
+
+	example, config := buildExample("Widgets", resource)
+
+	if example.Name != "widgets_widget_basic" || example.PrimaryResourceId != "example" {
+		t.Errorf("Expected example widgets_widget_basic with primary resource id example, found %+v", example)
+	}
+	if diff := cmp.Diff(map[string]string{"widget_id": "example-widget"}, example.Vars); diff != "" {
+		t.Errorf("example vars diff (-expected +got):\n%s", diff)
+	}
+
+	expected := `{{/* autogen_status: V2lkZ2V0
+Generated from the required fields of the API, with placeholder values. Review and refine before merging. */ -}}
+resource "google_widgets_widget" "{{$.PrimaryResourceId}}" {
+  location = "us-central1"
+  widget_id = "{{index $.Vars "widget_id"}}"
+  display_name = "example"
+}
+`
+	if diff := cmp.Diff(expected, config); diff != "" {
+		t.Errorf("example config diff (-expected +got):\n%s", diff)
+	}
+
+	tmpl, err := template.New(example.Name).Parse(config)
+	if err != nil {
+		t.Fatalf("Could not parse example config: %s", err)
+	}
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, example); err != nil {
+		t.Fatalf("Could not render example config: %s", err)
+	}
+	if !strings.HasPrefix(rendered.String(), `resource "google_widgets_widget" "example" {`) || !strings.Contains(rendered.String(), `widget_id = "example-widget"`) {
+		t.Errorf("Unexpected rendered example config:\n%s", rendered.String())
+	}
+}
+
+func TestBuildExampleNestedFields(t *testing.T) {
+	resource := api.Resource{
+		Name:       "Rule",
+		MinVersion: "beta",
+		Properties: []*api.Type{
+			{Name: "name", Type: "String", Required: true},
+			{Name: "priority", Type: "Integer", Required: true},
+			{Name: "description", Type: "String"},
+			{Name: "state", Type: "Enum", Required: true, Output: true, EnumValues: []string{"ACTIVE"}},
+			{Name: "match", Type: "NestedObject", Required: true, Properties: []*api.Type{
+				{Name: "action", Type: "Enum", Required: true, EnumValues: []string{"ALLOW", "DENY"}},
+				{Name: "ttl", Type: "String", Required: true, Validation: r.Validation{Function: "verify.ValidateDuration()"}},
+				{Name: "tags", Type: "KeyValuePairs", Required: true},
+			}},
+		},
+	}
+
+	_, config := buildExample("Firewall", resource)
+
+	expected := `resource "google_firewall_rule" "{{$.PrimaryResourceId}}" {
+  provider = google-beta
+  name = "{{index $.Vars "name"}}"
+  priority = 1
+  match {
+    action = "ALLOW"
+    ttl = "3600s"
+    tags = {
+      key = "value"
+    }
+  }
+}
+`
+	if _, body, _ := strings.Cut(config, "*/ -}}\n"); body != expected {
+		t.Errorf("example config diff (-expected +got):\n%s", cmp.Diff(expected, body))
+	}
+}
+
+func TestWriteExampleConfigKeepsExisting(t *testing.T) {
+	dir := t.TempDir()
+	example := &r.Examples{Name: "widgets_widget_basic"}
+	configPath := filepath.Join(dir, "widgets_widget_basic.tf.tmpl")
+
+	Parser{Examples: dir, Merge: true}.writeExampleConfig(example, "generated\n")
+	if b, err := os.ReadFile(configPath); err != nil || string(b) != "generated\n" {
+		t.Fatalf("Expected the example config to be written, found %q, %v", b, err)
+	}
+
+	if err := os.WriteFile(configPath, []byte("refined\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	Parser{Examples: dir, Merge: true}.writeExampleConfig(example, "generated\n")
+	if b, _ := os.ReadFile(configPath); string(b) != "refined\n" {
+		t.Errorf("Expected an existing example config to be kept, found %q", b)
+	}
+	Parser{Examples: dir}.writeExampleConfig(example, "generated\n")
+	if b, _ := os.ReadFile(configPath); string(b) != "generated\n" {
+		t.Errorf("Expected the example config to be overwritten, found %q", b)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// maxExpansionDepth bounds how deeply objects are expanded into nested
+// properties. Objects nested below it become JSON strings.
+const maxExpansionDepth = 10
+
+// truncation is a field whose schema was not expanded into properties, and
+// that holds its value as a JSON string instead.
+type truncation struct {
+	// Path of the field, starting with the resource name.
+	Path   string
+	Schema string
+	Reason string
+}
+
+func (t truncation) String() string {
+	return fmt.Sprintf("%s (%s): %s", t.Path, t.Schema, t.Reason)
+}
+
+// expansion tracks the objects enclosing a field while a schema is expanded
+// into nested properties, so that recursive and deeply nested schemas are
+// cut off rather than expanded forever.
+type expansion struct {
+	path        []string
+	schemas     []*openapi3.Schema
+	truncations *[]truncation
+}
+
+// newExpansion starts expanding the properties of a resource's schema.
+func newExpansion(resourceName string, schema *openapi3.Schema) expansion {
+	e := expansion{path: []string{resourceName}, truncations: &[]truncation{}}
+	if schema != nil {
+		e.schemas = []*openapi3.Schema{schema}
+	}
+	return e
+}
+
+// enter returns the expansion of the properties of schema, the object held
+// by the field name.
+func (e expansion) enter(name string, schema *openapi3.Schema) expansion {
+	return expansion{
+		path:        append(slices.Clip(e.path), name),
+		schemas:     append(slices.Clip(e.schemas), schema),
+		truncations: e.truncations,
+	}
+}
+
+// truncate reports whether the object held by the field name can't be
+// expanded, because it encloses the field or is nested too deeply, and
+// records it if so.
+func (e expansion) truncate(name string, obj *openapi3.SchemaRef) bool {
+	switch {
+	case slices.Contains(e.schemas, obj.Value):
+		e.record(name, obj, "recursive schema")
+	case len(e.schemas) >= maxExpansionDepth:
+		e.record(name, obj, fmt.Sprintf("nested more than %d objects deep", maxExpansionDepth))
+	default:
+		return false
+	}
+	return true
+}
+
+func (e expansion) record(name string, obj *openapi3.SchemaRef, reason string) {
+	schema := "inline schema"
+	if obj != nil && obj.Ref != "" {
+		schema = obj.Ref[strings.LastIndex(obj.Ref, "/")+1:]
+	}
+	path := strings.Join(append(slices.Clip(e.path), name), ".")
+	*e.truncations = append(*e.truncations, truncation{Path: strings.TrimPrefix(path, "."), Schema: schema, Reason: reason})
+}
+
+// setJsonField makes a field hold its value as a JSON string, for values
+// whose schema can't be expanded into properties.
+func setJsonField(field *api.Type) {
+	field.Type = "String"
+	field.EnumValues = nil
+	field.KeyName = ""
+	field.Properties = nil
+	field.ItemType = nil
+	field.ValueType = nil
+	field.StateFunc = "func(v interface{}) string { s, _ := structure.NormalizeJsonString(v); return s }"
+	field.CustomFlatten = "templates/terraform/custom_flatten/json_schema.tmpl"
+	field.CustomExpand = "templates/terraform/custom_expand/json_schema.tmpl"
+	field.Validation = r.Validation{Function: "validation.StringIsJSON"}
+}
+
+// resolveAllOf returns the schema that obj combines with allOf. A single
+// schema is used as is. Several are merged into one object, unless one of
+// them isn't an object, in which case it is used.
+func resolveAllOf(obj *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if len(obj.Value.AllOf) == 1 {
+		return obj.Value.AllOf[0]
+	}
+
+	merged := &openapi3.Schema{
+		Type:        &openapi3.Types{"object"},
+		Description: obj.Value.Description,
+		ReadOnly:    obj.Value.ReadOnly,
+		Properties:  make(openapi3.Schemas),
+	}
+	for _, s := range obj.Value.AllOf {
+		if s.Value == nil {
+			continue
+		}
+		if t := propType(s); len(t) > 0 && !t.Is("object") {
+			return s
+		}
+		if merged.Description == "" {
+			merged.Description = s.Value.Description
+		}
+		for k, p := range s.Value.Properties {
+			merged.Properties[k] = p
+		}
+		merged.Required = append(merged.Required, s.Value.Required...)
+	}
+	return merged.NewRef()
+}
+
+// logTruncations warns about every field of a product that holds a JSON
+// string because its schema couldn't be expanded, so they can be reviewed.
+func logTruncations(productPath string, truncations []truncation) {
+	slices.SortFunc(truncations, func(a, b truncation) int { return strings.Compare(a.String(), b.String()) })
+	truncations = slices.Compact(truncations)
+	if len(truncations) == 0 {
+		return
+	}
+	var report strings.Builder
+	fmt.Fprintf(&report, "Warning: %d fields in %s were not expanded and hold JSON strings:", len(truncations), productPath)
+	for _, t := range truncations {
+		fmt.Fprintf(&report, "\n  %s", t)
+	}
+	log.Print(report.String())
+}
@@ -0,0 +1,108 @@
+package openapi_generate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpansionRecursiveSchema(t *testing.T) {
+	expression := &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: make(openapi3.Schemas)}
+	expressionRef := &openapi3.SchemaRef{Ref: "#/components/schemas/Expression", Value: expression}
+	expression.Properties["value"] = openapi3.NewStringSchema().NewRef()
+	expression.Properties["negated"] = expressionRef
+	expression.Properties["operands"] = (&openapi3.Schema{Type: &openapi3.Types{"array"}, Items: expressionRef}).NewRef()
+	expression.Properties["literal"] = (&openapi3.Schema{Description: "Any value."}).NewRef()
+
+	filter := openapi3.NewObjectSchema().
+		WithPropertyRef("name", openapi3.NewStringSchema().NewRef()).
+		WithPropertyRef("expression", expressionRef)
+
+	e := newExpansion("Filter", filter)
+	props := e.objectProperties(filter)
+
+	var expr *api.Type
+	for _, p := range props {
+		if p.Name == "expression" {
+			expr = p
+		}
+	}
+	if expr == nil || expr.Type != "NestedObject" {
+		t.Fatalf("Expected expression to be a NestedObject, found %+v", expr)
+	}
+	types := make(map[string]*api.Type)
+	for _, p := range expr.Properties {
+		types[p.Name] = p
+	}
+	for _, name := range []string{"negated", "operands", "literal"} {
+		p := types[name]
+		if p == nil || p.Type != "String" || !p.IsJsonField() || p.Validation.Function != "validation.StringIsJSON" {
+			t.Errorf("Expected expression.%s to be a JSON string, found %+v", name, p)
+		}
+	}
+	if p := types["value"]; p == nil || p.IsJsonField() {
+		t.Errorf("Expected expression.value to be a plain String, found %+v", p)
+	}
+
+	var got []string
+	for _, tr := range *e.truncations {
+		got = append(got, tr.String())
+	}
+	expected := []string{
+		`Filter.expression.literal (inline schema): unknown type ""`,
+		"Filter.expression.negated (Expression): recursive schema",
+		"Filter.expression.operands (Expression): recursive schema",
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("truncations diff (-expected +got):\n%s", diff)
+	}
+}
+
+func TestExpansionDepth(t *testing.T) {
+	// level0.next.next... nests maxExpansionDepth+2 distinct objects.
+	leaf := openapi3.NewObjectSchema().WithPropertyRef("value", openapi3.NewStringSchema().NewRef())
+	schema := leaf
+	for i := 0; i < maxExpansionDepth+2; i++ {
+		schema = openapi3.NewObjectSchema().WithPropertyRef("next", schema.NewRef())
+	}
+
+	e := newExpansion("Deep", schema)
+	props := e.objectProperties(schema)
+
+	depth := 0
+	for len(props) == 1 && props[0].Type == "NestedObject" {
+		depth++
+		props = props[0].Properties
+	}
+	if depth != maxExpansionDepth-1 {
+		t.Errorf("Expected %d nested objects, found %d", maxExpansionDepth-1, depth)
+	}
+	if len(props) != 1 || !props[0].IsJsonField() {
+		t.Errorf("Expected the deepest field to be a JSON string, found %+v", props)
+	}
+	if len(*e.truncations) != 1 {
+		t.Errorf("Expected 1 truncation, found %v", *e.truncations)
+	}
+}
+
+func TestPropType(t *testing.T) {
+	cases := []struct {
+		name     string
+		schema   *openapi3.Schema
+		expected openapi3.Types
+	}{
+		{"typed", openapi3.NewStringSchema(), openapi3.Types{"string"}},
+		{"allOf", &openapi3.Schema{AllOf: openapi3.SchemaRefs{(&openapi3.Schema{}).NewRef(), openapi3.NewIntegerSchema().NewRef()}}, openapi3.Types{"integer"}},
+		{"properties", &openapi3.Schema{Properties: openapi3.Schemas{"a": openapi3.NewStringSchema().NewRef()}}, openapi3.Types{"object"}},
+		{"items", &openapi3.Schema{Items: openapi3.NewStringSchema().NewRef()}, openapi3.Types{"array"}},
+		{"unknown", &openapi3.Schema{}, nil},
+	}
+	for _, tc := range cases {
+		if got := propType(tc.schema.NewRef()); fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+			t.Errorf("%s: propType() = %v, expected %v", tc.name, got, tc.expected)
+		}
+	}
+}
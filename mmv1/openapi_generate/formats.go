@@ -0,0 +1,185 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// scalarType returns the MMv1 type of a string, integer, number or boolean
+// schema. The format is checked first, as JSON encodes 64-bit integers as
+// strings.
+func scalarType(schema *openapi3.Schema) string {
+	switch schema.Format {
+	case "int32", "int64":
+		// Integer fields are flattened from both JSON numbers and strings.
+		return "Integer"
+	case "uint64", "fixed64":
+		// Values may not fit in a signed 64-bit integer.
+		return "String"
+	case "date-time", "google-datetime":
+		return "Time"
+	case "google-duration", "byte", "google-fieldmask":
+		return "String"
+	}
+
+	switch {
+	case schema.Type.Is("integer"):
+		return "Integer"
+	case schema.Type.Is("number"):
+		return "Double"
+	case schema.Type.Is("boolean"):
+		return "Boolean"
+	default:
+		return "String"
+	}
+}
+
+// schemaValidation returns the validation of a string schema, from its
+// format or, failing that, its pattern or length.
+func schemaValidation(schema *openapi3.Schema) r.Validation {
+	if !schema.Type.Is("string") || len(schema.Enum) > 0 {
+		return r.Validation{}
+	}
+	switch schema.Format {
+	case "google-duration":
+		return r.Validation{Function: "verify.ValidateDuration()"}
+	case "byte":
+		return r.Validation{Function: "verify.ValidateBase64String"}
+	case "int64", "uint64", "fixed64", "date-time", "google-datetime", "google-fieldmask":
+		return r.Validation{}
+	}
+
+	switch {
+	case schema.Pattern != "":
+		return r.Validation{Regex: schema.Pattern}
+	case schema.MaxLength != nil:
+		return r.Validation{Function: fmt.Sprintf("validation.StringLenBetween(%d, %d)", schema.MinLength, *schema.MaxLength)}
+	case schema.MinLength > 0:
+		return r.Validation{Function: "validation.StringIsNotEmpty"}
+	}
+	return r.Validation{}
+}
+
+// arraySize sets the number of items an Array field must have.
+func arraySize(field *api.Type, schema *openapi3.Schema) {
+	if schema.MinItems > 0 {
+		minSize := int(schema.MinItems)
+		field.MinSize = &minSize
+	}
+	if schema.MaxItems != nil {
+		maxSize := int(*schema.MaxItems)
+		field.MaxSize = &maxSize
+	}
+}
+
+// isSensitive reports whether a schema holds secrets, such as passwords or
+// private keys, that shouldn't be shown in plans.
+func isSensitive(obj *openapi3.SchemaRef) bool {
+	if obj.Value.Format == "password" {
+		return true
+	}
+	if sensitive, ok := obj.Extensions["x-google-sensitive"]; ok && anyToBool(sensitive) {
+		return true
+	}
+	sensitive, ok := obj.Value.Extensions["x-google-sensitive"]
+	return ok && anyToBool(sensitive)
+}
+
+// objectProperties returns the properties of an object schema. Its oneOf
+// alternatives, which each set one of a group of fields, become
+// exactly_one_of groups, holding field names until qualifyExactlyOneOf
+// replaces them with their paths in the resource.
+func (e expansion) objectProperties(schema *openapi3.Schema) []*api.Type {
+	if len(schema.OneOf) == 0 {
+		return e.buildProperties(schema.Properties, schema.Required)
+	}
+
+	props := maps.Clone(schema.Properties)
+	if props == nil {
+		props = make(openapi3.Schemas)
+	}
+	var group []string
+	for _, alternative := range schema.OneOf {
+		if alternative.Value == nil {
+			continue
+		}
+		for _, name := range alternative.Value.Required {
+			if !slices.Contains(group, name) {
+				group = append(group, name)
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(alternative.Value.Properties)) {
+			if _, ok := props[name]; !ok {
+				props[name] = alternative.Value.Properties[name]
+			}
+			if !slices.Contains(group, name) {
+				group = append(group, name)
+			}
+		}
+	}
+
+	properties := e.buildProperties(props, schema.Required)
+	if len(group) < 2 {
+		return properties
+	}
+	slices.Sort(group)
+	for _, p := range properties {
+		if slices.Contains(group, p.Name) {
+			p.ExactlyOneOf = slices.Clone(group)
+		}
+	}
+	return properties
+}
+
+// qualifyExactlyOneOf replaces the field names in exactly_one_of groups with
+// the fields' paths in the resource, e.g. config.0.name.
+func qualifyExactlyOneOf(properties []*api.Type, prefix string) {
+	for _, p := range properties {
+		for i, name := range p.ExactlyOneOf {
+			p.ExactlyOneOf[i] = prefix + google.Underscore(name)
+		}
+
+		childPrefix := fmt.Sprintf("%s%s.0.", prefix, google.Underscore(p.Name))
+		qualifyExactlyOneOf(p.Properties, childPrefix)
+		if p.ItemType != nil {
+			qualifyExactlyOneOf(p.ItemType.Properties, childPrefix)
+		}
+		if p.ValueType != nil {
+			clearExactlyOneOf(p.ValueType.Properties)
+		}
+	}
+}
+
+// clearExactlyOneOf drops exactly_one_of groups inside maps, where fields
+// have no path to refer to them by.
+func clearExactlyOneOf(properties []*api.Type) {
+	for _, p := range properties {
+		p.ExactlyOneOf = nil
+		clearExactlyOneOf(p.Properties)
+		if p.ItemType != nil {
+			clearExactlyOneOf(p.ItemType.Properties)
+		}
+		if p.ValueType != nil {
+			clearExactlyOneOf(p.ValueType.Properties)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// missingFromSpecComment marks properties of an existing resource that are no
+// longer in the API spec.
+const missingFromSpecComment = "# TODO: not found in the API spec. Remove this field if it was removed from the API."
+
+// typeKinds groups MMv1 types by the JSON type they represent, so that
+// hand-tuned types such as ResourceRef or Time are only replaced when the
+// API type actually changes.
+var typeKinds = map[string]string{
+	"String":              "string",
+	"Enum":                "string",
+	"Time":                "string",
+	"ResourceRef":         "string",
+	"Fingerprint":         "string",
+	"Integer":             "integer",
+	"Double":              "number",
+	"Boolean":             "boolean",
+	"NestedObject":        "object",
+	"Array":               "array",
+	"Map":                 "map",
+	"KeyValuePairs":       "map",
+	"KeyValueLabels":      "map",
+	"KeyValueAnnotations": "map",
+}
+
+// typeKeys are the keys describing the type of a property, replaced together
+// when the type changes.
+var typeKeys = []string{"type", "enum_values", "key_name", "item_type", "value_type", "properties"}
+
+// mergeResourceYaml merges a freshly generated resource into the YAML of an
+// existing one, editing the existing document in place so that comments,
+// key order and formatting are kept and the result diffs cleanly.
+//
+// Only parameters and properties are merged, matched by name. Properties
+// new in the spec are added, the type of existing ones is updated when the
+// underlying JSON type changed, enum values are added, and output and
+// immutable are set when the spec sets them. Properties that are no longer
+// in the spec are marked with a comment rather than removed. Everything
+// else, such as descriptions, examples and custom code, is left untouched.
+func mergeResourceYaml(existing, generated []byte) ([]byte, error) {
+	var existingDoc, generatedDoc yaml.Node
+	if err := yaml.Unmarshal(existing, &existingDoc); err != nil {
+		return nil, fmt.Errorf("error parsing existing resource: %w", err)
+	}
+	if err := yaml.Unmarshal(generated, &generatedDoc); err != nil {
+		return nil, fmt.Errorf("error parsing generated resource: %w", err)
+	}
+	if len(existingDoc.Content) == 0 || existingDoc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("existing resource is not a YAML mapping")
+	}
+
+	existingRoot, generatedRoot := existingDoc.Content[0], generatedDoc.Content[0]
+	for _, key := range []string{"parameters", "properties"} {
+		mergeKey(existingRoot, generatedRoot, key)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&existingDoc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mergeKey merges the value of key in generated into existing, adding it if
+// it is missing.
+func mergeKey(existing, generated *yaml.Node, key string) {
+	generatedValue := mappingValue(generated, key)
+	if generatedValue == nil {
+		if existingValue := mappingValue(existing, key); existingValue != nil && existingValue.Kind == yaml.SequenceNode {
+			// Every property of the list disappeared from the spec.
+			mergeProperties(existingValue, &yaml.Node{Kind: yaml.SequenceNode})
+		}
+		return
+	}
+	existingValue := mappingValue(existing, key)
+	if existingValue == nil {
+		setMappingValue(existing, key, generatedValue)
+		return
+	}
+	switch key {
+	case "parameters", "properties":
+		mergeProperties(existingValue, generatedValue)
+	case "item_type", "value_type":
+		mergeProperty(existingValue, generatedValue)
+	}
+}
+
+// mergeProperties merges two lists of properties by name.
+func mergeProperties(existing, generated *yaml.Node) {
+	if existing.Kind != yaml.SequenceNode || generated.Kind != yaml.SequenceNode {
+		return
+	}
+
+	var generatedNames []string
+	for _, g := range generated.Content {
+		generatedNames = append(generatedNames, propertyName(g))
+	}
+
+	for _, e := range existing.Content {
+		if slices.Contains(generatedNames, propertyName(e)) {
+			e.HeadComment = removeComment(e.HeadComment, missingFromSpecComment)
+			continue
+		}
+		if !isClientSide(e) && !strings.Contains(e.HeadComment, missingFromSpecComment) {
+			e.HeadComment = strings.TrimSpace(e.HeadComment + "\n" + missingFromSpecComment)
+		}
+	}
+
+	// New properties are inserted after the closest preceding property in
+	// the spec, or at the end of the list.
+	insertAt := 0
+	for _, g := range generated.Content {
+		if i := propertyIndex(existing, propertyName(g)); i >= 0 {
+			mergeProperty(existing.Content[i], g)
+			insertAt = i + 1
+			continue
+		}
+		existing.Content = slices.Insert(existing.Content, insertAt, g)
+		insertAt++
+	}
+}
+
+// mergeProperty merges a generated property into an existing one.
+func mergeProperty(existing, generated *yaml.Node) {
+	if existing.Kind != yaml.MappingNode || generated.Kind != yaml.MappingNode {
+		return
+	}
+
+	existingType, generatedType := scalarValue(existing, "type"), scalarValue(generated, "type")
+	if typeKind(existingType) != typeKind(generatedType) {
+		for _, key := range typeKeys {
+			if v := mappingValue(generated, key); v != nil {
+				setMappingValue(existing, key, v)
+			} else {
+				deleteMappingValue(existing, key)
+			}
+		}
+	} else {
+		if existingValues, generatedValues := mappingValue(existing, "enum_values"), mappingValue(generated, "enum_values"); existingValues != nil && generatedValues != nil {
+			for _, v := range generatedValues.Content {
+				if !slices.ContainsFunc(existingValues.Content, func(e *yaml.Node) bool { return e.Value == v.Value }) {
+					existingValues.Content = append(existingValues.Content, v)
+				}
+			}
+		}
+		for _, key := range []string{"item_type", "value_type", "properties"} {
+			mergeKey(existing, generated, key)
+		}
+	}
+
+	for _, flag := range []string{"output", "immutable"} {
+		if scalarValue(generated, flag) == "true" && scalarValue(existing, flag) != "true" {
+			setMappingValue(existing, flag, mappingValue(generated, flag))
+		}
+	}
+}
+
+func typeKind(t string) string {
+	if kind, ok := typeKinds[t]; ok {
+		return kind
+	}
+	return t
+}
+
+// isClientSide reports whether a property is virtual, and so never in the
+// spec.
+func isClientSide(property *yaml.Node) bool {
+	return scalarValue(property, "client_side") == "true"
+}
+
+func propertyName(property *yaml.Node) string {
+	return scalarValue(property, "name")
+}
+
+func propertyIndex(properties *yaml.Node, name string) int {
+	return slices.IndexFunc(properties.Content, func(p *yaml.Node) bool { return propertyName(p) == name })
+}
+
+func removeComment(comment, line string) string {
+	return strings.TrimSpace(strings.ReplaceAll(comment, line, ""))
+}
+
+// mappingValue returns the value of key in a mapping node, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func scalarValue(mapping *yaml.Node, key string) string {
+	if v := mappingValue(mapping, key); v != nil && v.Kind == yaml.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
+
+// setMappingValue replaces the value of key, or appends the key.
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+func deleteMappingValue(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = slices.Delete(mapping.Content, i, i+2)
+			return
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package openapi_generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMergeResourceYaml(t *testing.T) {
+	existing := `name: Widget
+# Hand-written description.
+description: |
+  A widget, for use with gadgets.
+base_url: projects/{{project}}/locations/{{location}}/widgets
+custom_code:
+  pre_create: templates/terraform/pre_create/widget.go.tmpl
+examples:
+  - name: widget_basic
+    primary_resource_id: example
+properties:
+  - name: displayName
+    type: String
+    required: true
+    description: The name shown in the console.
+    diff_suppress_func: tpgresource.CaseDiffSuppress
+  - name: network
+    type: ResourceRef
+    description: The network of the widget.
+    resource: Network
+    imports: selfLink
+  - name: size
+    type: String
+    description: The size of the widget.
+  - name: state
+    type: Enum
+    description: The state of the widget.
+    enum_values:
+      - ACTIVE
+  - name: legacyField
+    type: String
+    description: No longer in the API.
+  - name: deletionProtection
+    type: Boolean
+    client_side: true
+    description: Prevents deletion.
+`
+	generated := `name: Widget
+description: Description
+base_url: projects/{{project}}/locations/{{location}}/widgets
+properties:
+  - name: createTime
+    type: String
+    description: The creation time.
+    output: true
+  - name: displayName
+    type: String
+    description: The display name.
+  - name: network
+    type: String
+    description: The network.
+    immutable: true
+  - name: size
+    type: Integer
+    description: The size.
+  - name: state
+    type: Enum
+    description: The state.
+    output: true
+    enum_values:
+      - ACTIVE
+      - DELETING
+`
+	expected := `name: Widget
+# Hand-written description.
+description: |
+  A widget, for use with gadgets.
+base_url: projects/{{project}}/locations/{{location}}/widgets
+custom_code:
+  pre_create: templates/terraform/pre_create/widget.go.tmpl
+examples:
+  - name: widget_basic
+    primary_resource_id: example
+properties:
+  - name: createTime
+    type: String
+    description: The creation time.
+    output: true
+  - name: displayName
+    type: String
+    required: true
+    description: The name shown in the console.
+    diff_suppress_func: tpgresource.CaseDiffSuppress
+  - name: network
+    type: ResourceRef
+    description: The network of the widget.
+    resource: Network
+    imports: selfLink
+    immutable: true
+  - name: size
+    type: Integer
+    description: The size of the widget.
+  - name: state
+    type: Enum
+    description: The state of the widget.
+    enum_values:
+      - ACTIVE
+      - DELETING
+    output: true
+  # TODO: not found in the API spec. Remove this field if it was removed from the API.
+  - name: legacyField
+    type: String
+    description: No longer in the API.
+  - name: deletionProtection
+    type: Boolean
+    client_side: true
+    description: Prevents deletion.
+`
+
+	merged, err := mergeResourceYaml([]byte(existing), []byte(generated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expected, string(merged)); diff != "" {
+		t.Errorf("mergeResourceYaml() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Merging is idempotent.
+	again, err := mergeResourceYaml(merged, []byte(generated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(merged), string(again)); diff != "" {
+		t.Errorf("merging twice changed the result (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteYamlMerge(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "widgets_v1.discovery.json")
+	if err := os.WriteFile(input, testDiscovery, 0644); err != nil {
+		t.Fatal(err)
+	}
+	parser := Parser{Output: t.TempDir(), Merge: true}
+	if err := parser.WriteYaml(input); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(parser.Output, "widgets", "Widget.yaml")
+	generated, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(generated), "description: The display name of the widget.", "description: Hand-tuned.", 1)
+	if edited == string(generated) {
+		t.Fatal("displayName description not found in the generated resource")
+	}
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	productPath := filepath.Join(parser.Output, "widgets", "product.yaml")
+	if err := os.WriteFile(productPath, []byte("name: HandWritten\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := parser.WriteYaml(input); err != nil {
+		t.Fatal(err)
+	}
+	if product, err := os.ReadFile(productPath); err != nil || string(product) != "name: HandWritten\n" {
+		t.Errorf("regenerating overwrote product.yaml: %q, %v", product, err)
+	}
+	merged, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(edited, string(merged)); diff != "" {
+		t.Errorf("regenerating changed the edited resource (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteYamlMergeKeepsHeader(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "widgets_v1.discovery.json")
+	if err := os.WriteFile(input, testDiscovery, 0644); err != nil {
+		t.Fatal(err)
+	}
+	parser := Parser{Output: t.TempDir(), Merge: true}
+	if err := parser.WriteYaml(input); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(parser.Output, "widgets", "Widget.yaml")
+	generated, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Existing resources have licenses from the year they were added.
+	edited := strings.Replace(string(generated), "Copyright 2026", "Copyright 2024", 1)
+	if edited == string(generated) {
+		t.Fatal("license not found in the generated resource")
+	}
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := parser.WriteYaml(input); err != nil {
+		t.Fatal(err)
+	}
+	merged, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(edited, string(merged)); diff != "" {
+		t.Errorf("regenerating changed the resource (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// isIamPath reports whether path calls the IAM method, as
+// {resource}:method or {resource}/method.
+func isIamPath(path, method string) bool {
+	return strings.HasSuffix(path, ":"+method) || strings.HasSuffix(path, "/"+method)
+}
+
+// attachIamOperations attaches the getIamPolicy or setIamPolicy operation at
+// path to the resource whose item path it extends.
+func attachIamOperations(resources map[string]*resource, path string, pathValue *openapi3.PathItem) {
+	iamMethod := "setIamPolicy"
+	if isIamPath(path, "getIamPolicy") {
+		iamMethod = "getIamPolicy"
+	}
+	resourcePath := strings.TrimSuffix(strings.TrimSuffix(path, ":"+iamMethod), "/"+iamMethod)
+	for _, r := range resources {
+		if !slices.Contains(r.itemPaths(), resourcePath) {
+			continue
+		}
+		for method, op := range pathValue.Operations() {
+			iamOp := &resourceOp{path: path, method: method, op: op}
+			if iamMethod == "getIamPolicy" {
+				r.getIamPolicy = iamOp
+			} else {
+				r.setIamPolicy = iamOp
+			}
+		}
+	}
+}
+
+// itemPaths returns the paths that address a single instance of the
+// resource.
+func (r *resource) itemPaths() []string {
+	var paths []string
+	for _, op := range []*resourceOp{r.get, r.update, r.delete} {
+		if op != nil {
+			paths = append(paths, op.path)
+		}
+	}
+	return paths
+}
+
+// attachOperationMetadata fills in the resource fields that can be derived
+// from the resource's operations, beyond its CRUD URLs.
+func attachOperationMetadata(resource api.Resource, in *resource) api.Resource {
+	if in.update != nil && hasParameter(in.update.op, "updateMask") {
+		resource.UpdateMask = true
+	}
+
+	if in.get != nil {
+		resource.ReadQueryParams = readQueryParams(in.get.op)
+	}
+
+	if in.list != nil {
+		if key := collectionKey(in.list.op); key != "" {
+			if key != google.Camelize(google.Plural(resource.Name), "lower") {
+				resource.CollectionUrlKey = key
+			}
+			// Without a Get method the resource can only be read by finding
+			// it in its collection.
+			if in.get == nil {
+				resource.NestedQuery = &r.NestedQuery{Keys: []string{key}}
+				resource.SelfLink = resource.BaseUrl
+			}
+		}
+	}
+
+	if in.getIamPolicy != nil && in.setIamPolicy != nil {
+		resource.IamPolicy = iamPolicy(in.getIamPolicy, in.setIamPolicy)
+	}
+
+	return resource
+}
+
+func hasParameter(op *openapi3.Operation, name string) bool {
+	return parameter(op, name) != nil
+}
+
+func parameter(op *openapi3.Operation, name string) *openapi3.Parameter {
+	if op == nil {
+		return nil
+	}
+	for _, p := range op.Parameters {
+		if p.Value != nil && p.Value.Name == name {
+			return p.Value
+		}
+	}
+	return nil
+}
+
+// readQueryParams requests the full view of resources that support AIP-157
+// partial responses, as the default view may leave fields out.
+func readQueryParams(op *openapi3.Operation) string {
+	view := parameter(op, "view")
+	if view == nil || view.Schema == nil || view.Schema.Value == nil {
+		return ""
+	}
+	for _, v := range view.Schema.Value.Enum {
+		if value := fmt.Sprintf("%v", v); value == "FULL" || strings.HasSuffix(value, "_VIEW_FULL") || strings.HasSuffix(value, "_FULL") {
+			return fmt.Sprintf("?view=%s", value)
+		}
+	}
+	return ""
+}
+
+// collectionKey returns the name of the repeated field in a List response
+// that holds the resources.
+func collectionKey(op *openapi3.Operation) string {
+	schema := responseSchema(op)
+	if schema == nil {
+		return ""
+	}
+	for _, k := range slices.Sorted(maps.Keys(schema.Properties)) {
+		prop := schema.Properties[k].Value
+		if prop != nil && prop.Type.Is("array") && prop.Items != nil && prop.Items.Value != nil && prop.Items.Value.Type.Is("object") {
+			return k
+		}
+	}
+	return ""
+}
+
+// responseSchema returns the JSON schema of a successful response.
+func responseSchema(op *openapi3.Operation) *openapi3.Schema {
+	if op == nil || op.Responses == nil {
+		return nil
+	}
+	for _, status := range []int{http.StatusOK, 0} {
+		var response *openapi3.ResponseRef
+		if status == 0 {
+			response = op.Responses.Default()
+		} else {
+			response = op.Responses.Status(status)
+		}
+		if response == nil || response.Value == nil {
+			continue
+		}
+		if media := response.Value.Content.Get("application/json"); media != nil && media.Schema != nil {
+			return media.Schema.Value
+		}
+	}
+	return nil
+}
+
+func iamPolicy(get, set *resourceOp) *r.IamPolicy {
+	policy := r.NewIamPolicy()
+	if strings.HasSuffix(get.path, ":getIamPolicy") {
+		policy.MethodNameSeparator = ":"
+	}
+	policy.FetchIamPolicyVerb = get.method
+	policy.SetIamPolicyVerb = set.method
+
+	switch {
+	case hasParameter(get.op, "options.requestedPolicyVersion"):
+		policy.IamConditionsRequestType = "QUERY_PARAM_NESTED"
+	case hasParameter(get.op, "optionsRequestedPolicyVersion"):
+		policy.IamConditionsRequestType = "QUERY_PARAM"
+	case get.op.RequestBody != nil && get.op.RequestBody.Value != nil:
+		if media := get.op.RequestBody.Value.Content.Get("application/json"); media != nil && media.Schema != nil && media.Schema.Value != nil {
+			if _, ok := media.Schema.Value.Properties["options"]; ok {
+				policy.IamConditionsRequestType = "REQUEST_BODY"
+			}
+		}
+	}
+	return policy
+}
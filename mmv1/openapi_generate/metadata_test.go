@@ -0,0 +1,104 @@
+package openapi_generate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAttachOperationMetadata(t *testing.T) {
+	doc, err := parseDiscovery(testDiscovery)
+	if err != nil {
+		t.Fatalf("Could not parse Discovery document %s", err)
+	}
+	res := findResources(doc)
+
+	type metadata struct {
+		UpdateMask       bool
+		ReadQueryParams  string
+		CollectionUrlKey string
+		SelfLink         string
+		NestedQuery      *r.NestedQuery
+		IamPolicy        *r.IamPolicy
+	}
+	iamPolicy := r.NewIamPolicy()
+	iamPolicy.MethodNameSeparator = ":"
+	iamPolicy.IamConditionsRequestType = "QUERY_PARAM_NESTED"
+
+	cases := []struct {
+		name     string
+		resource api.Resource
+		expected metadata
+	}{
+		{
+			name:     "Widget",
+			resource: buildResource("Widget", res["Widget"], doc),
+			expected: metadata{
+				UpdateMask:      true,
+				ReadQueryParams: "?view=WIDGET_VIEW_FULL",
+				SelfLink:        "projects/{{project}}/locations/{{location}}/widgets/{{widget_id}}",
+				IamPolicy:       iamPolicy,
+			},
+		},
+		{
+			// Gadgets have no Get method, so are read from their collection.
+			name:     "Gadget",
+			resource: buildResource("Gadget", res["Gadget"], doc),
+			expected: metadata{
+				CollectionUrlKey: "items",
+				SelfLink:         "projects/{{project}}/locations/{{location}}/gadgets",
+				NestedQuery:      &r.NestedQuery{Keys: []string{"items"}},
+			},
+		},
+		{
+			name:     "Settings",
+			resource: buildSingleton("Settings", res["Settings"], doc),
+			expected: metadata{
+				SelfLink: "projects/{{project}}/locations/{{location}}/settings",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := metadata{
+				UpdateMask:       tc.resource.UpdateMask,
+				ReadQueryParams:  tc.resource.ReadQueryParams,
+				CollectionUrlKey: tc.resource.CollectionUrlKey,
+				SelfLink:         tc.resource.SelfLink,
+				NestedQuery:      tc.resource.NestedQuery,
+				IamPolicy:        tc.resource.IamPolicy,
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("attachOperationMetadata() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAttachIamOperations(t *testing.T) {
+	for _, path := range []string{
+		"/v1/widgets/{widget}:getIamPolicy",
+		"/v1/widgets/{widget}:setIamPolicy",
+		"/v1/widgets/{widget}/getIamPolicy",
+		"/v1/widgets/{widget}/setIamPolicy",
+	} {
+		t.Run(path, func(t *testing.T) {
+			widget := &resource{get: &resourceOp{path: "/v1/widgets/{widget}"}}
+			pathValue := &openapi3.PathItem{Post: &openapi3.Operation{}}
+			attachIamOperations(map[string]*resource{"Widget": widget}, path, pathValue)
+
+			iamOp := widget.setIamPolicy
+			if strings.HasSuffix(path, "getIamPolicy") {
+				iamOp = widget.getIamPolicy
+			}
+			if iamOp == nil || iamOp.path != path {
+				t.Errorf("expected the operation at %s to be attached to the widget", path)
+			}
+		})
+	}
+}
@@ -19,8 +19,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"maps"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -47,70 +49,222 @@ var header []byte
 type Parser struct {
 	Folder string
 	Output string
+
+	// Format of the input files, FormatOpenAPI or FormatDiscovery. If empty,
+	// files ending in .discovery.json are read as Discovery documents and
+	// all others as OpenAPI.
+	Format string
+
+	// Merge regenerates into existing resource YAML files instead of
+	// overwriting them, keeping hand edits. See mergeResourceYaml.
+	Merge bool
+
+	// Examples is the directory the Terraform config templates of generated
+	// examples are written to. If empty, they are not written.
+	Examples string
+
+	// Files are the spec files to read. If empty, every file in Folder is
+	// read.
+	Files []string
+
+	// Resources limits generation to the resources whose names match one of
+	// these glob patterns. If empty, every resource is generated.
+	Resources []string
+
+	// DryRun reports the files that would be added or changed instead of
+	// writing them.
+	DryRun bool
 }
 
 func NewOpenapiParser(folder, output string) Parser {
-	wd, err := os.Getwd()
-	if err != nil {
-		log.Fatalf("%v", err)
-	}
-
 	parser := Parser{
-		Folder: path.Join(wd, folder),
-		Output: path.Join(wd, output),
+		Folder: absPath(folder),
+		Output: absPath(output),
 	}
 
 	return parser
 }
 
-func (parser Parser) Run() {
-	f, err := os.Open(parser.Folder)
+// absPath returns p relative to the working directory, unless it is already
+// absolute.
+func absPath(p string) string {
+	abs, err := filepath.Abs(p)
 	if err != nil {
 		log.Fatalf("%v", err)
-		return
 	}
-	defer f.Close()
-	files, err := f.Readdirnames(0)
-	if err != nil {
-		log.Fatalf("%v", err)
+	return abs
+}
+
+// Run generates the products of the spec files. Products whose specs can't
+// be read are reported in the returned error, after the others have been
+// generated.
+func (parser Parser) Run() error {
+	filePaths := parser.Files
+	if len(filePaths) == 0 {
+		files, err := os.ReadDir(parser.Folder)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			if !file.IsDir() {
+				filePaths = append(filePaths, path.Join(parser.Folder, file.Name()))
+			}
+		}
 	}
 
 	// check if folder is empty
-	if len(files) == 0 {
-		log.Fatalf("No OpenAPI files found in %s", parser.Folder)
+	if len(filePaths) == 0 {
+		return fmt.Errorf("no OpenAPI files found in %s", parser.Folder)
 	}
 
-	for _, file := range files {
-		parser.WriteYaml(path.Join(parser.Folder, file))
+	var errs []error
+	for _, group := range groupByProduct(filePaths) {
+		if err := parser.WriteYaml(group...); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return errors.Join(errs...)
 }
 
-func (parser Parser) WriteYaml(filePath string) {
-	log.Printf("Reading from file path %s", filePath)
-
-	ctx := context.Background()
-	loader := &openapi3.Loader{Context: ctx, IsExternalRefsAllowed: true}
-	doc, _ := loader.LoadFromFile(filePath)
-	_ = doc.Validate(ctx)
-
-	resources := findResources(doc)
-	productPath := buildProduct(filePath, parser.Output, doc, header)
+// WriteYaml writes the product and resources described by the specs at
+// filePaths, which are the specs of different versions of the same API, such
+// as widgets_v1.yaml and widgets_v1beta.yaml. Resources and properties that
+// are only in the specs of less stable versions get their min_version.
+func (parser Parser) WriteYaml(filePaths ...string) error {
+	var docs []*openapi3.T
+	var errs []error
+	for _, filePath := range filePaths {
+		doc, err := parser.loadDoc(filePath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filePath, err))
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	sortByVersion(docs)
+	for i := 1; i < len(docs); i++ {
+		if versionName(docs[i].Info.Version) == versionName(docs[i-1].Info.Version) {
+			return fmt.Errorf("%v: found more than one spec for the %s version of %s", filePaths, versionName(docs[i].Info.Version), productName(filePaths[0]))
+		}
+	}
 
-	log.Printf("Generated product %+v/product.yaml", productPath)
-	for name, resource := range resources {
-		if resource.create == nil {
-			if resource.update == nil {
+	resources := make(map[string]api.Resource)
+	var truncations []truncation
+	for _, doc := range docs {
+		version := versionName(doc.Info.Version)
+		for name, in := range findResources(doc) {
+			if !parser.selected(name) {
 				continue
 			}
-			singleton := buildSingleton(name, resource, doc)
+			var built api.Resource
+			if in.create == nil {
+				if in.update == nil {
+					continue
+				}
+				built = buildSingleton(name, in, doc)
+			} else {
+				built = buildResource(name, in, doc)
+			}
+			truncations = append(truncations, in.truncations...)
 
-			parser.writeResource(singleton, productPath)
-		} else {
-			resource := buildResource(name, resource, doc)
+			if existing, ok := resources[name]; ok {
+				resources[name] = mergeVersion(&existing, built, version)
+			} else {
+				resources[name] = mergeVersion(nil, built, version)
+			}
+		}
+	}
+	if len(resources) == 0 && len(parser.Resources) > 0 {
+		log.Printf("No resources of %s match %v, skipping", productName(filePaths[0]), parser.Resources)
+		return nil
+	}
 
-			parser.writeResource(resource, productPath)
+	productPath := parser.buildProduct(filePaths[0], docs)
+
+	productName := apiProductName(docs[0])
+	for _, name := range slices.Sorted(maps.Keys(resources)) {
+		resource := resources[name]
+		// Examples are only generated along with their config templates
+		var example *r.Examples
+		var config string
+		if parser.Examples != "" {
+			example, config = buildExample(productName, resource)
+			resource.Examples = []*r.Examples{example}
+		}
+		parser.writeResource(resource, productPath)
+		if example != nil {
+			parser.writeExampleConfig(example, config)
+		}
+	}
+	logTruncations(productPath, truncations)
+	return nil
+}
+
+// selected reports whether a resource matches the Resources allowlist.
+func (parser Parser) selected(name string) bool {
+	if len(parser.Resources) == 0 {
+		return true
+	}
+	for _, pattern := range parser.Resources {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// loadDoc reads a spec. Specs that can be read but aren't valid are still
+// used, as many published specs have minor errors, but the errors are
+// logged.
+func (parser Parser) loadDoc(filePath string) (*openapi3.T, error) {
+	log.Printf("Reading from file path %s", filePath)
+
+	switch format := inputFormat(filePath, parser.Format); format {
+	case FormatDiscovery:
+		return loadDiscovery(filePath)
+	case FormatOpenAPI:
+		ctx := context.Background()
+		loader := &openapi3.Loader{Context: ctx, IsExternalRefsAllowed: true}
+		doc, err := loader.LoadFromFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing OpenAPI spec: %w", err)
+		}
+		if err := doc.Validate(ctx); err != nil {
+			log.Printf("Warning: %s is not a valid OpenAPI spec: %v", filePath, err)
+		}
+		if len(doc.Servers) == 0 || doc.Info == nil {
+			return nil, fmt.Errorf("error parsing OpenAPI spec: servers and info are required")
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unknown input format %q", format)
+	}
+}
+
+// writeFile writes a generated file or, with DryRun, prints whether it would
+// be added, changed or left unchanged.
+func (parser Parser) writeFile(filePath string, content []byte) {
+	if parser.DryRun {
+		status := "changed"
+		if existing, err := os.ReadFile(filePath); err != nil {
+			status = "added"
+		} else if bytes.Equal(existing, content) {
+			status = "unchanged"
 		}
+		fmt.Printf("%s %s\n", status, filePath)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		log.Fatalf("error creating output directory %v: %v", filepath.Dir(filePath), err)
 	}
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		log.Fatalf("error writing file %v", err)
+	}
+	log.Printf("Generated %s", filePath)
 }
 
 func (parser Parser) writeResource(resource api.Resource, productPath string) {
@@ -125,33 +279,81 @@ func (parser Parser) writeResource(resource api.Resource, productPath string) {
 		log.Fatalf("Failed to encode: %v", err)
 	}
 
-	f, err := os.Create(resourceOutPathMarshal)
-	if err != nil {
-		log.Fatalf("error creating resource file %v", err)
-	}
-	_, err = f.Write(header)
-	if err != nil {
-		log.Fatalf("error writing resource file header %v", err)
-	}
-	_, err = f.Write(yamlContent.Bytes())
-	if err != nil {
-		log.Fatalf("error writing resource file %v", err)
+	head := header
+	if parser.Merge {
+		merged, existingHead, err := mergeExistingResource(resourceOutPathMarshal, yamlContent.Bytes())
+		if err != nil {
+			log.Fatalf("error merging into existing resource file %s: %v", resourceOutPathMarshal, err)
+		}
+		if merged != nil {
+			yamlContent.Reset()
+			yamlContent.Write(merged)
+			head = existingHead
+		}
 	}
-	err = f.Close()
-	if err != nil {
-		log.Fatalf("error closing resource file %v", err)
+
+	parser.writeFile(resourceOutPathMarshal, append(slices.Clip(head), yamlContent.Bytes()...))
+}
+
+// mergeExistingResource merges generated resource YAML into the resource
+// file at path. It returns the merged YAML and, separately, the file's
+// original license header, or nil if the file doesn't exist.
+func mergeExistingResource(path string, generated []byte) (merged, head []byte, err error) {
+	existing, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	// Refuse to merge into a file that doesn't load, as it would be
+	// rewritten without the user noticing.
+	if err := api.Compile(path, &api.Resource{}); err != nil {
+		return nil, nil, err
+	}
+	head, existing = splitHeader(existing)
+	merged, err = mergeResourceYaml(existing, generated)
+	return merged, head, err
+}
+
+// splitHeader splits the leading comment block of a YAML file, such as its
+// license, and the document start marker that follows it from the rest of
+// the file.
+func splitHeader(content []byte) (head, body []byte) {
+	rest := content
+	for len(rest) > 0 {
+		line, next, _ := bytes.Cut(rest, []byte("\n"))
+		trimmed := bytes.TrimSpace(line)
+		if bytes.Equal(trimmed, []byte("---")) {
+			rest = next
+			break
+		}
+		if len(trimmed) > 0 && trimmed[0] != '#' {
+			break
+		}
+		rest = next
 	}
-	log.Printf("Generated resource %s", resourceOutPathMarshal)
+	n := len(content) - len(rest)
+	return content[:n], content[n:]
 }
 
 type resourceOp struct {
 	path  string
 	async bool
+	// The HTTP method and operation, used to derive resource metadata.
+	method string
+	op     *openapi3.Operation
 }
 
 type resource struct {
 	// nil if not defined
 	create, update, delete *resourceOp
+	get, list              *resourceOp
+	getIamPolicy           *resourceOp
+	setIamPolicy           *resourceOp
+
+	// Schemas that were not expanded into properties when the resource was
+	// built.
+	truncations []truncation
 }
 
 func anyToBool(a any) bool {
@@ -178,7 +380,7 @@ func buildOperation(resourcePath string, op *openapi3.Operation, prefix string)
 		if a, ok := op.Extensions["x-google-lro"]; ok {
 			async = anyToBool(a)
 		}
-		return resourceName, &resourceOp{path: resourcePath, async: async}
+		return resourceName, &resourceOp{path: resourcePath, async: async, op: op}
 	}
 	return "", nil
 }
@@ -202,42 +404,70 @@ func findResources(doc *openapi3.T) map[string]*resource {
 			getDefault(name).delete = op
 		}
 		if name, op := buildOperation(key, pathValue.Patch, "Update"); op != nil {
+			op.method = http.MethodPatch
 			getDefault(name).update = op
 		}
+		// Some APIs, such as Discovery update methods, replace the resource
+		// with PUT instead. PATCH is preferred when a resource has both.
+		if name, op := buildOperation(key, pathValue.Put, "Update"); op != nil {
+			op.method = http.MethodPut
+			if r := getDefault(name); r.update == nil {
+				r.update = op
+			}
+		}
+	}
+
+	// Reads and IAM methods don't define resources, so they are only
+	// attached to the resources found above.
+	for key, pathValue := range doc.Paths.Map() {
+		if isIamPath(key, "getIamPolicy") || isIamPath(key, "setIamPolicy") {
+			attachIamOperations(resources, key, pathValue)
+			continue
+		}
+		if name, op := buildOperation(key, pathValue.Get, "Get"); op != nil {
+			if r, ok := resources[name]; ok {
+				r.get = op
+			}
+		}
+		if name, op := buildOperation(key, pathValue.Get, "List"); op != nil {
+			for resourceName, r := range resources {
+				if name == resourceName || name == google.Plural(resourceName) {
+					r.list = op
+				}
+			}
+		}
 	}
 
 	return resources
 }
 
-func buildProduct(filePath, output string, root *openapi3.T, header []byte) string {
+// buildProduct writes product.yaml, unless keepExisting is set and it
+// already exists, and returns the product directory.
+func (parser Parser) buildProduct(filePath string, docs []*openapi3.T) string {
+	root := docs[0]
 
-	version := root.Info.Version
-	server := root.Servers[0].URL
-
-	productName := strings.Split(filepath.Base(filePath), "_")[0]
-	productPath := filepath.Join(output, productName)
-
-	if err := os.MkdirAll(productPath, os.ModePerm); err != nil {
-		log.Fatalf("error creating product output directory %v: %v", productPath, err)
-	}
+	productName := productName(filePath)
+	productPath := filepath.Join(parser.Output, productName)
 
 	apiProduct := &api.Product{}
-	apiVersion := &product.Version{}
-
-	apiVersion.BaseUrl = fmt.Sprintf("%s/%s/", server, version)
-	// TODO figure out how to tell the API version
-	apiVersion.Name = "ga"
-	apiProduct.Versions = []*product.Version{apiVersion}
+	for _, doc := range docs {
+		apiVersion := &product.Version{}
+		apiVersion.BaseUrl = fmt.Sprintf("%s/%s/", doc.Servers[0].URL, doc.Info.Version)
+		apiVersion.Name = versionName(doc.Info.Version)
+		apiProduct.Versions = append(apiProduct.Versions, apiVersion)
+	}
 
-	// Standard titling is "Service Name API"
-	displayName := strings.Replace(root.Info.Title, " API", "", 1)
-	apiProduct.Name = strings.ReplaceAll(displayName, " ", "")
-	apiProduct.DisplayName = displayName
+	apiProduct.Name = apiProductName(root)
+	apiProduct.DisplayName = productDisplayName(root)
 
 	//Scopes should be added soon to OpenAPI, until then use global scope
 	apiProduct.Scopes = []string{"https://www.googleapis.com/auth/cloud-platform"}
 
-	productOutPathMarshal := filepath.Join(output, fmt.Sprintf("/%s/product.yaml", productName))
+	productOutPathMarshal := filepath.Join(parser.Output, fmt.Sprintf("/%s/product.yaml", productName))
+	if _, err := os.Stat(productOutPathMarshal); parser.Merge && err == nil {
+		log.Printf("Keeping existing product %s", productOutPathMarshal)
+		return productPath
+	}
 
 	// Default yaml marshaller
 	bytes, err := yaml.Marshal(apiProduct)
@@ -245,25 +475,20 @@ func buildProduct(filePath, output string, root *openapi3.T, header []byte) stri
 		log.Fatalf("error marshalling yaml %v: %v", productOutPathMarshal, err)
 	}
 
-	f, err := os.Create(productOutPathMarshal)
-	if err != nil {
-		log.Fatalf("error creating product file %v", err)
-	}
-	_, err = f.Write(header)
-	if err != nil {
-		log.Fatalf("error writing product file header %v", err)
-	}
-	_, err = f.Write(bytes)
-	if err != nil {
-		log.Fatalf("error writing product file %v", err)
-	}
-	err = f.Close()
-	if err != nil {
-		log.Fatalf("error closing product file %v", err)
-	}
+	parser.writeFile(productOutPathMarshal, append(slices.Clip(header), bytes...))
 	return productPath
 }
 
+// productDisplayName returns the name of the product without the "API"
+// suffix, as standard titling is "Service Name API".
+func productDisplayName(root *openapi3.T) string {
+	return strings.Replace(root.Info.Title, " API", "", 1)
+}
+
+func apiProductName(root *openapi3.T) string {
+	return strings.ReplaceAll(productDisplayName(root), " ", "")
+}
+
 func baseUrl(resourcePath string) string {
 	base := strings.ReplaceAll(resourcePath, "{", "{{")
 	base = strings.ReplaceAll(base, "}", "}}")
@@ -292,11 +517,12 @@ func buildSingleton(resourceName string, in *resource, root *openapi3.T) api.Res
 	resource := api.Resource{}
 	resourcePath := in.update.path
 
-	op := root.Paths.Find(resourcePath).Patch
+	op := in.update.op
 	parsedObjects := parseOpenApi(resourcePath, resourceName, op)
 
 	parameters := parsedObjects[0].([]*api.Type)
 	properties := parsedObjects[1].([]*api.Type)
+	in.truncations = parsedObjects[3].([]truncation)
 
 	baseUrl := baseUrl(resourcePath)
 	selfLink := baseUrl
@@ -307,11 +533,13 @@ func buildSingleton(resourceName string, in *resource, root *openapi3.T) api.Res
 	resource.Properties = properties
 	resource.SelfLink = selfLink
 	resource.CreateUrl = fmt.Sprintf("%s=?updateMask=*", baseUrl)
+	if in.update.method == http.MethodPut {
+		resource.CreateUrl = baseUrl
+	}
 
-	resource.CreateVerb = "PATCH"
+	resource.CreateVerb = in.update.method
 
-	resource.UpdateVerb = "PATCH"
-	resource.UpdateMask = true
+	resource.UpdateVerb = in.update.method
 	if in.update.async {
 		resource.AutogenAsync = true
 		async := api.NewAsync()
@@ -326,6 +554,7 @@ func buildSingleton(resourceName string, in *resource, root *openapi3.T) api.Res
 	resource.ExcludeDelete = true
 
 	resource = attachStandardFunctionality(resource)
+	resource = attachOperationMetadata(resource, in)
 
 	return resource
 }
@@ -339,6 +568,7 @@ func buildResource(resourceName string, in *resource, root *openapi3.T) api.Reso
 
 	parameters := parsedObjects[0].([]*api.Type)
 	properties := parsedObjects[1].([]*api.Type)
+	in.truncations = parsedObjects[3].([]truncation)
 	queryParam := parsedObjects[2].(string)
 
 	baseUrl := baseUrl(resourcePath)
@@ -363,8 +593,7 @@ func buildResource(resourceName string, in *resource, root *openapi3.T) api.Reso
 	}
 
 	if in.update != nil {
-		resource.UpdateVerb = "PATCH"
-		resource.UpdateMask = true
+		resource.UpdateVerb = in.update.method
 		if in.update.async {
 			resource.Async.Actions = append(resource.Async.Actions, "update")
 		}
@@ -376,6 +605,7 @@ func buildResource(resourceName string, in *resource, root *openapi3.T) api.Reso
 	}
 
 	resource = attachStandardFunctionality(resource)
+	resource = attachOperationMetadata(resource, in)
 
 	return resource
 }
@@ -387,13 +617,6 @@ func attachStandardFunctionality(resource api.Resource) api.Resource {
 	resource.IdFormat = resource.SelfLink
 	resource.ImportFormat = []string{resource.SelfLink}
 
-	example := r.Examples{}
-	example.Name = "name_of_example_file"
-	example.PrimaryResourceId = "example"
-	example.Vars = map[string]string{"resource_name": "test-resource"}
-
-	resource.Examples = []*r.Examples{&example}
-
 	resourceNameBytes := []byte(resource.Name)
 	// Write the status as an encoded string to flag when a YAML file has been
 	// copy and pasted without actually using this tool
@@ -408,13 +631,16 @@ func parseOpenApi(resourcePath, resourceName string, op *openapi3.Operation) []a
 	}
 	returnArray := []any{}
 
+	body := op.RequestBody.Value.Content["application/json"].Schema
+	e := newExpansion(resourceName, body.Value)
+
 	parameters := []*api.Type{}
 	var idParam string
 	for _, param := range op.Parameters {
 		if strings.Contains(strings.ToLower(param.Value.Name), strings.ToLower(resourceName)) {
 			idParam = param.Value.Name
 		}
-		paramObj := WriteObject(param.Value.Name, param.Value.Schema, propType(param.Value.Schema), true)
+		paramObj := e.writeObject(param.Value.Name, param.Value.Schema, propType(param.Value.Schema), true)
 		description := param.Value.Description
 		if strings.TrimSpace(description) == "" {
 			description = "No description"
@@ -430,24 +656,46 @@ func parseOpenApi(resourcePath, resourceName string, op *openapi3.Operation) []a
 		parameters = append(parameters, &paramObj)
 	}
 
-	properties := buildProperties(op.RequestBody.Value.Content["application/json"].Schema.Value.Properties, op.RequestBody.Value.Content["application/json"].Schema.Value.Required)
+	properties := e.objectProperties(body.Value)
+	qualifyExactlyOneOf(properties, "")
 
 	returnArray = append(returnArray, parameters)
 	returnArray = append(returnArray, properties)
 	returnArray = append(returnArray, idParam)
+	returnArray = append(returnArray, *e.truncations)
 
 	return returnArray
 }
 
+// propType returns the JSON type of a schema. allOf schemas take the type of
+// the first schema they combine that has one, and untyped schemas are
+// inferred from their fields. It returns nil if the type can't be told.
 func propType(prop *openapi3.SchemaRef) openapi3.Types {
-	if len(prop.Value.AllOf) > 0 {
-		return *prop.Value.AllOf[0].Value.Type
-	} else {
+	if prop == nil || prop.Value == nil {
+		return nil
+	}
+	for _, s := range prop.Value.AllOf {
+		if t := propType(s); len(t) > 0 {
+			return t
+		}
+	}
+	if prop.Value.Type != nil && len(*prop.Value.Type) > 0 {
 		return *prop.Value.Type
 	}
+	switch {
+	case len(prop.Value.Properties) > 0 || prop.Value.AdditionalProperties.Schema != nil || len(prop.Value.OneOf) > 0:
+		return openapi3.Types{"object"}
+	case prop.Value.Items != nil:
+		return openapi3.Types{"array"}
+	}
+	return nil
 }
 
 func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, urlParam bool) api.Type {
+	return newExpansion("", nil).writeObject(name, obj, objType, urlParam)
+}
+
+func (e expansion) writeObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, urlParam bool) api.Type {
 	var field api.Type
 
 	switch name {
@@ -461,30 +709,24 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 	additionalDescription := ""
 
 	if len(obj.Value.AllOf) > 0 {
-		obj = obj.Value.AllOf[0]
-		objType = *obj.Value.Type
+		obj = resolveAllOf(obj)
+		objType = propType(obj)
+	}
+
+	var typeName string
+	if len(objType) > 0 {
+		typeName = objType[0]
 	}
 
 	field.Name = name
-	switch objType[0] {
-	case "string":
-		field.Type = "String"
-		if len(obj.Value.Enum) > 0 {
-			var enums []string
-			for _, enum := range obj.Value.Enum {
-				if strings.HasSuffix(fmt.Sprintf("%v", enum), "_UNSPECIFIED") {
-					continue
-				}
-				enums = append(enums, fmt.Sprintf("%v", enum))
-			}
-			additionalDescription = fmt.Sprintf("\n Possible values:\n %s", strings.Join(enums, "\n"))
-		}
-	case "integer":
-		field.Type = "Integer"
-	case "number":
-		field.Type = "Double"
-	case "boolean":
-		field.Type = "Boolean"
+	switch typeName {
+	case "string", "integer", "number", "boolean":
+		field.Type = scalarType(obj.Value)
+		field.Validation = schemaValidation(obj.Value)
+		if enums := enumValues(obj.Value); len(enums) > 0 {
+			field.Type = "Enum"
+			field.EnumValues = enums
+		}
 	case "object":
 		if field.Name == "labels" {
 			// Standard labels implementation
@@ -501,40 +743,67 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 		if obj.Value.AdditionalProperties.Schema != nil && obj.Value.AdditionalProperties.Schema.Value.Type.Is("string") {
 			// AdditionalProperties with type string is a string -> string map
 			field.Type = "KeyValuePairs"
+			// KeyValuePairs values can't be validated, so enum values are
+			// listed in the description instead.
+			if enums := enumValues(obj.Value.AdditionalProperties.Schema.Value); len(enums) > 0 {
+				additionalDescription = fmt.Sprintf("\n Possible values:\n %s", strings.Join(enums, "\n"))
+			}
 			break
 		}
 
+		expanded := obj
+		if obj.Value.AdditionalProperties.Schema != nil {
+			expanded = obj.Value.AdditionalProperties.Schema
+		}
+		if e.truncate(name, expanded) {
+			setJsonField(&field)
+			break
+		}
+		inner := e.enter(name, expanded.Value)
+
 		field.Type = "NestedObject"
 		if obj.Value.AdditionalProperties.Schema != nil {
 			field.Type = "Map"
 			field.KeyName = "TODO: CHANGEME"
 			var valueType api.Type
 			valueType.Type = "NestedObject"
-			valueType.Properties = buildProperties(obj.Value.AdditionalProperties.Schema.Value.Properties, obj.Value.AdditionalProperties.Schema.Value.Required)
+			valueType.Properties = inner.objectProperties(expanded.Value)
 			field.ValueType = &valueType
 		} else {
-			field.Properties = buildProperties(obj.Value.Properties, obj.Value.Required)
+			field.Properties = inner.objectProperties(obj.Value)
 		}
 	case "array":
+		itemType := propType(obj.Value.Items)
+		if len(itemType) == 0 {
+			e.record(name, obj, "unknown item type")
+			setJsonField(&field)
+			break
+		}
+		if itemType.Is("object") && e.truncate(name, obj.Value.Items) {
+			setJsonField(&field)
+			break
+		}
+
 		field.Type = "Array"
+		arraySize(&field, obj.Value)
 		var subField api.Type
-		typ := *obj.Value.Items.Value.Type
-		switch typ[0] {
-		case "string":
-			subField.Type = "String"
-		case "integer":
-			subField.Type = "Integer"
-		case "number":
-			subField.Type = "Double"
-		case "boolean":
-			subField.Type = "Boolean"
+		items := obj.Value.Items.Value
+		switch itemType[0] {
+		case "string", "integer", "number", "boolean":
+			subField.Type = scalarType(items)
+			field.ItemValidation = schemaValidation(items)
+			if enums := enumValues(items); len(enums) > 0 {
+				subField.Type = "Enum"
+				subField.EnumValues = enums
+			}
 		case "object":
 			subField.Type = "NestedObject"
-			subField.Properties = buildProperties(obj.Value.Items.Value.Properties, obj.Value.Items.Value.Required)
+			subField.Properties = e.enter(name, items).objectProperties(items)
 		}
 		field.ItemType = &subField
 	default:
-		panic(fmt.Sprintf("Failed to identify field type for %s %s", field.Name, objType[0]))
+		e.record(name, obj, fmt.Sprintf("unknown type %q", typeName))
+		setJsonField(&field)
 	}
 
 	description := fmt.Sprintf("%s %s", obj.Value.Description, additionalDescription)
@@ -566,14 +835,32 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 		field.Immutable = true
 	}
 
+	if isSensitive(obj) {
+		field.Sensitive = true
+	}
+
 	return field
 }
 
-func buildProperties(props openapi3.Schemas, required []string) []*api.Type {
+// enumValues returns the values of a string enum, leaving out the
+// _UNSPECIFIED default value that can't be set.
+func enumValues(schema *openapi3.Schema) []string {
+	var enums []string
+	for _, enum := range schema.Enum {
+		value := fmt.Sprintf("%v", enum)
+		if strings.HasSuffix(value, "_UNSPECIFIED") {
+			continue
+		}
+		enums = append(enums, value)
+	}
+	return enums
+}
+
+func (e expansion) buildProperties(props openapi3.Schemas, required []string) []*api.Type {
 	properties := []*api.Type{}
 	for _, k := range slices.Sorted(maps.Keys(props)) {
 		prop := props[k]
-		propObj := WriteObject(k, prop, propType(prop), false)
+		propObj := e.writeObject(k, prop, propType(prop), false)
 		if slices.Contains(required, k) {
 			propObj.Required = true
 		}
@@ -2,8 +2,14 @@ package openapi_generate
 
 import (
 	_ "embed"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
@@ -58,3 +64,206 @@ func TestFindResources(t *testing.T) {
 		t.Error("Singleton update should be found")
 	}
 }
+
+func TestEnumTypes(t *testing.T) {
+	ctx := t.Context()
+	loader := &openapi3.Loader{Context: ctx, IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromData(testData)
+	if err != nil {
+		t.Fatalf("Could not load data %s", err)
+	}
+
+	foodSchema := doc.Components.Schemas["Food"]
+	props := make(map[string]*api.Type)
+	for _, p := range newExpansion("Food", foodSchema.Value).buildProperties(foodSchema.Value.Properties, foodSchema.Value.Required) {
+		props[p.Name] = p
+	}
+
+	flavor := props["flavor"]
+	if flavor.Type != "Enum" || !slices.Equal(flavor.EnumValues, []string{"SWEET", "SAVORY"}) {
+		t.Errorf("Expected flavor to be an Enum of SWEET, SAVORY, found %s %v", flavor.Type, flavor.EnumValues)
+	}
+	if strings.Contains(flavor.Description, "Possible values") {
+		t.Errorf("Expected Enum description not to list values, found %q", flavor.Description)
+	}
+
+	allergens := props["allergens"]
+	if allergens.Type != "Array" || allergens.ItemType.Type != "Enum" || !slices.Equal(allergens.ItemType.EnumValues, []string{"NUTS", "GLUTEN"}) {
+		t.Errorf("Expected allergens to be an Array of Enum NUTS, GLUTEN, found %s of %s %v", allergens.Type, allergens.ItemType.Type, allergens.ItemType.EnumValues)
+	}
+
+	// KeyValuePairs values can't be validated, so the values are documented.
+	servingSizes := props["servingSizes"]
+	if servingSizes.Type != "KeyValuePairs" || !strings.Contains(servingSizes.Description, "SMALL\nLARGE") || strings.Contains(servingSizes.Description, "SIZE_UNSPECIFIED") {
+		t.Errorf("Expected servingSizes to be KeyValuePairs listing SMALL, LARGE, found %s %q", servingSizes.Type, servingSizes.Description)
+	}
+}
+
+// The generated YAML for a map of string enums must load and validate like a
+// handwritten resource.
+func TestEnumMapCompiles(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "test_api.yaml")
+	if err := os.WriteFile(input, testData, 0644); err != nil {
+		t.Fatal(err)
+	}
+	output := t.TempDir()
+	parser := Parser{Files: []string{input}, Output: output, Resources: []string{"Food"}}
+	if err := parser.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(output)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected a single product directory, found %v: %v", entries, err)
+	}
+	productDir := filepath.Join(output, entries[0].Name())
+	var product api.Product
+	if err := api.Compile(filepath.Join(productDir, "product.yaml"), &product); err != nil {
+		t.Fatal(err)
+	}
+	var food api.Resource
+	if err := api.Compile(filepath.Join(productDir, "Food.yaml"), &food); err != nil {
+		t.Fatal(err)
+	}
+	food.TargetVersionName = "ga"
+	food.SetDefault(&product)
+	extra, err := food.AddExtraFields(food.PropertiesWithExcluded(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	food.Properties = extra
+	food.SetDefault(&product)
+	for _, err := range food.Validate() {
+		t.Error(err)
+	}
+
+	servingSizes := food.AllPropertiesInVersion()
+	i := slices.IndexFunc(servingSizes, func(p *api.Type) bool { return p.Name == "servingSizes" })
+	if i < 0 {
+		t.Fatal("Expected a servingSizes property")
+	}
+	if got := servingSizes[i]; got.Type != "KeyValuePairs" || !strings.Contains(got.Description, "SMALL") {
+		t.Errorf("Expected servingSizes to compile as KeyValuePairs listing its values, found %s %q", got.Type, got.Description)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	ctx := t.Context()
+	loader := &openapi3.Loader{Context: ctx, IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromData(testData)
+	if err != nil {
+		t.Fatalf("Could not load data %s", err)
+	}
+	if err := doc.Validate(ctx); err != nil {
+		t.Fatalf("Could not validate data %s", err)
+	}
+
+	recipeSchema := doc.Components.Schemas["Recipe"]
+	properties := newExpansion("Recipe", recipeSchema.Value).objectProperties(recipeSchema.Value)
+	qualifyExactlyOneOf(properties, "")
+	props := make(map[string]*api.Type)
+	for _, p := range properties {
+		props[p.Name] = p
+	}
+
+	cases := []struct {
+		name       string
+		typ        string
+		validation r.Validation
+	}{
+		{"servings", "Integer", r.Validation{}},
+		{"calories", "String", r.Validation{}},
+		{"cookTime", "String", r.Validation{Function: "verify.ValidateDuration()"}},
+		{"publishTime", "Time", r.Validation{}},
+		{"photo", "String", r.Validation{Function: "verify.ValidateBase64String"}},
+		{"code", "String", r.Validation{Regex: "^[a-z]+$"}},
+		{"title", "String", r.Validation{Function: "validation.StringLenBetween(1, 64)"}},
+	}
+	for _, tc := range cases {
+		p := props[tc.name]
+		if p == nil {
+			t.Errorf("Expected property %s", tc.name)
+			continue
+		}
+		if p.Type != tc.typ || p.Validation != tc.validation {
+			t.Errorf("Expected %s to be a %s with validation %+v, found %s with %+v", tc.name, tc.typ, tc.validation, p.Type, p.Validation)
+		}
+	}
+
+	steps := props["steps"]
+	if steps.MinSize == nil || *steps.MinSize != 1 || steps.MaxSize == nil || *steps.MaxSize != 10 {
+		t.Errorf("Expected steps to have between 1 and 10 items, found %v %v", steps.MinSize, steps.MaxSize)
+	}
+	if want := "validation.StringLenBetween(0, 256)"; steps.ItemValidation.Function != want {
+		t.Errorf("Expected steps item validation %q, found %+v", want, steps.ItemValidation)
+	}
+
+	if !props["secretIngredient"].Sensitive {
+		t.Error("Expected secretIngredient to be sensitive")
+	}
+
+	sourceProps := make(map[string]*api.Type)
+	for _, p := range props["source"].Properties {
+		sourceProps[p.Name] = p
+	}
+	want := []string{"source.0.book", "source.0.website"}
+	for _, name := range []string{"book", "website"} {
+		if p := sourceProps[name]; p == nil || !slices.Equal(p.ExactlyOneOf, want) {
+			t.Errorf("Expected source.%s to be exactly one of %v, found %+v", name, want, p)
+		}
+	}
+}
+
+func TestRunReportsUnparseableSpecs(t *testing.T) {
+	input := t.TempDir()
+	if err := os.WriteFile(filepath.Join(input, "widgets_v1.discovery.json"), testDiscovery, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(input, "broken_v1.yaml"), []byte("{not: [valid"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	parser := Parser{Folder: input, Output: t.TempDir()}
+	err := parser.Run()
+	if err == nil || !strings.Contains(err.Error(), "broken_v1.yaml") {
+		t.Errorf("Expected an error for broken_v1.yaml, found %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(parser.Output, "widgets", "Widget.yaml")); err != nil {
+		t.Errorf("Expected the other specs to be generated: %s", err)
+	}
+}
+
+func TestRunResourcesAndDryRun(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "widgets_v1.discovery.json")
+	if err := os.WriteFile(input, testDiscovery, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output := t.TempDir()
+	parser := Parser{Files: []string{input}, Output: output, Examples: filepath.Join(output, "examples"), Resources: []string{"W*"}, DryRun: true}
+	if err := parser.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(output); len(entries) != 0 {
+		t.Errorf("Expected a dry run not to write files, found %v", entries)
+	}
+
+	parser.DryRun = false
+	if err := parser.Run(); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(filepath.Join(output, "widgets"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if want := []string{"Widget.yaml", "product.yaml"}; !slices.Equal(names, want) {
+		t.Errorf("Expected only %v to be generated, found %v", want, names)
+	}
+	if _, err := os.Stat(filepath.Join(output, "examples", "widgets_widget_basic.tf.tmpl")); err != nil {
+		t.Errorf("Expected the Widget example to be generated: %s", err)
+	}
+}
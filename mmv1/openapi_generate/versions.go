@@ -0,0 +1,178 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// productName returns the product a spec file belongs to, the part of its
+// name before the API version, e.g. widgets for widgets_v1beta.yaml.
+func productName(filePath string) string {
+	fileName := strings.TrimSuffix(filepath.Base(filePath), discoveryExtension)
+	return strings.Split(fileName, "_")[0]
+}
+
+// groupByProduct groups spec files by product, so that the specs of each
+// version of an API are read together.
+func groupByProduct(filePaths []string) [][]string {
+	groups := make(map[string][]string)
+	for _, filePath := range filePaths {
+		name := productName(filePath)
+		groups[name] = append(groups[name], filePath)
+	}
+	var grouped [][]string
+	for _, name := range slices.Sorted(maps.Keys(groups)) {
+		grouped = append(grouped, groups[name])
+	}
+	return grouped
+}
+
+// versionName returns the MMv1 version of an API version, e.g. beta for
+// v1beta1.
+func versionName(apiVersion string) string {
+	switch {
+	case strings.Contains(apiVersion, "alpha"):
+		return "alpha"
+	case strings.Contains(apiVersion, "beta"):
+		return "beta"
+	default:
+		return "ga"
+	}
+}
+
+// sortByVersion orders specs from the most to the least stable version.
+func sortByVersion(docs []*openapi3.T) {
+	slices.SortStableFunc(docs, func(a, b *openapi3.T) int {
+		return slices.Index(product.ORDER, versionName(a.Info.Version)) - slices.Index(product.ORDER, versionName(b.Info.Version))
+	})
+}
+
+// mergeVersion adds the resource built from the spec of a less stable
+// version to the one built from the more stable specs, which is nil if the
+// resource isn't in them. Resources, properties and IAM policies that are
+// only in the later spec are marked with its version as their min_version.
+// Differences that can't be marked with a version are logged instead, to be
+// handled by hand.
+func mergeVersion(existing *api.Resource, later api.Resource, version string) api.Resource {
+	if existing == nil {
+		if version != "ga" {
+			later.MinVersion = version
+		}
+		return later
+	}
+	existing.Parameters = mergeVersionProperties(existing.Parameters, later.Parameters, version)
+	existing.Properties = mergeVersionProperties(existing.Properties, later.Properties, version)
+	if existing.IamPolicy == nil && later.IamPolicy != nil {
+		existing.IamPolicy = later.IamPolicy
+		existing.IamPolicy.MinVersion = version
+	}
+	for _, d := range versionDifferences(existing, &later) {
+		log.Printf("Warning: %s %s only in %s, and is not merged", existing.Name, d, version)
+	}
+	return *existing
+}
+
+// versionDifferences describes the behaviour of later that differs from
+// existing and has no per-version setting in MMv1.
+func versionDifferences(existing, later *api.Resource) []string {
+	var diffs []string
+	if existing.Immutable && !later.Immutable {
+		diffs = append(diffs, "update is")
+	}
+	if !existing.UpdateMask && later.UpdateMask {
+		diffs = append(diffs, "update mask is")
+	}
+	if later.Async != nil {
+		for _, action := range later.Async.Actions {
+			if existing.Async == nil || !slices.Contains(existing.Async.Actions, action) {
+				diffs = append(diffs, fmt.Sprintf("async %s is", action))
+			}
+		}
+	}
+	return append(diffs, enumDifferences("", existing.AllProperties(), later.AllProperties())...)
+}
+
+// enumDifferences describes the enum values of later properties that are
+// missing from the matching existing properties.
+func enumDifferences(prefix string, existing, later []*api.Type) []string {
+	var diffs []string
+	for _, l := range later {
+		i := slices.IndexFunc(existing, func(e *api.Type) bool { return e.Name == l.Name })
+		if i < 0 {
+			continue
+		}
+		e := existing[i]
+		name := prefix + l.Name
+		diffs = append(diffs, enumValueDifferences(name, e, l)...)
+		diffs = append(diffs, enumDifferences(name+".", e.Properties, l.Properties)...)
+		if e.ItemType != nil && l.ItemType != nil {
+			diffs = append(diffs, enumValueDifferences(name+"[]", e.ItemType, l.ItemType)...)
+			diffs = append(diffs, enumDifferences(name+"[].", e.ItemType.Properties, l.ItemType.Properties)...)
+		}
+		if e.ValueType != nil && l.ValueType != nil {
+			diffs = append(diffs, enumValueDifferences(name+"{}", e.ValueType, l.ValueType)...)
+			diffs = append(diffs, enumDifferences(name+"{}.", e.ValueType.Properties, l.ValueType.Properties)...)
+		}
+	}
+	return diffs
+}
+
+func enumValueDifferences(name string, existing, later *api.Type) []string {
+	var added []string
+	for _, v := range later.EnumValues {
+		if !slices.Contains(existing.EnumValues, v) {
+			added = append(added, v)
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s enum values %s are", name, strings.Join(added, ", "))}
+}
+
+// mergeVersionProperties adds the properties that are only in later to
+// existing, each after the property preceding it in later, and marks them
+// with version.
+func mergeVersionProperties(existing, later []*api.Type, version string) []*api.Type {
+	insertAt := 0
+	for _, l := range later {
+		i := slices.IndexFunc(existing, func(e *api.Type) bool { return e.Name == l.Name })
+		if i < 0 {
+			l.MinVersion = version
+			existing = slices.Insert(existing, insertAt, l)
+			insertAt++
+			continue
+		}
+		e := existing[i]
+		e.Properties = mergeVersionProperties(e.Properties, l.Properties, version)
+		if e.ItemType != nil && l.ItemType != nil {
+			e.ItemType.Properties = mergeVersionProperties(e.ItemType.Properties, l.ItemType.Properties, version)
+		}
+		if e.ValueType != nil && l.ValueType != nil {
+			e.ValueType.Properties = mergeVersionProperties(e.ValueType.Properties, l.ValueType.Properties, version)
+		}
+		insertAt = i + 1
+	}
+	return existing
+}
@@ -0,0 +1,241 @@
+package openapi_generate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVersionName(t *testing.T) {
+	for apiVersion, expected := range map[string]string{
+		"v1":       "ga",
+		"v2":       "ga",
+		"v1beta":   "beta",
+		"v1beta1":  "beta",
+		"v1alpha1": "alpha",
+	} {
+		if got := versionName(apiVersion); got != expected {
+			t.Errorf("versionName(%q) = %q, expected %q", apiVersion, got, expected)
+		}
+	}
+}
+
+func TestGroupByProduct(t *testing.T) {
+	got := groupByProduct([]string{
+		"specs/widgets_v1beta.discovery.json",
+		"specs/gadgets_v1.yaml",
+		"specs/widgets_v1.discovery.json",
+	})
+	expected := [][]string{
+		{"specs/gadgets_v1.yaml"},
+		{"specs/widgets_v1beta.discovery.json", "specs/widgets_v1.discovery.json"},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("groupByProduct() diff (-expected +got):\n%s", diff)
+	}
+}
+
+func TestMergeVersionProperties(t *testing.T) {
+	ga := []*api.Type{
+		{Name: "name"},
+		{Name: "config", Properties: []*api.Type{{Name: "enabled"}}},
+		{Name: "rules", ItemType: &api.Type{Properties: []*api.Type{{Name: "action"}}}},
+	}
+	beta := []*api.Type{
+		{Name: "name"},
+		{Name: "color"},
+		{Name: "config", Properties: []*api.Type{{Name: "enabled"}, {Name: "mode"}}},
+		{Name: "rules", ItemType: &api.Type{Properties: []*api.Type{{Name: "priority"}, {Name: "action"}}}},
+		{Name: "tier"},
+	}
+
+	merged := mergeVersionProperties(ga, beta, "beta")
+
+	versions := make(map[string]string)
+	var walk func(prefix string, props []*api.Type)
+	walk = func(prefix string, props []*api.Type) {
+		for _, p := range props {
+			versions[prefix+p.Name] = p.MinVersion
+			walk(prefix+p.Name+".", p.Properties)
+			if p.ItemType != nil {
+				walk(prefix+p.Name+".", p.ItemType.Properties)
+			}
+		}
+	}
+	walk("", merged)
+	expected := map[string]string{
+		"name":           "",
+		"color":          "beta",
+		"config":         "",
+		"config.enabled": "",
+		"config.mode":    "beta",
+		"rules":          "",
+		"rules.priority": "beta",
+		"rules.action":   "",
+		"tier":           "beta",
+	}
+	if diff := cmp.Diff(expected, versions); diff != "" {
+		t.Errorf("mergeVersionProperties() min versions diff (-expected +got):\n%s", diff)
+	}
+
+	var names []string
+	for _, p := range merged {
+		names = append(names, p.Name)
+	}
+	if diff := cmp.Diff([]string{"name", "color", "config", "rules", "tier"}, names); diff != "" {
+		t.Errorf("mergeVersionProperties() order diff (-expected +got):\n%s", diff)
+	}
+}
+
+func TestMergeVersionDifferences(t *testing.T) {
+	ga := &api.Resource{
+		Name:      "Widget",
+		Immutable: true,
+		Async:     &api.Async{Actions: []string{"create"}},
+		Properties: []*api.Type{
+			{Name: "state", Type: "Enum", EnumValues: []string{"ACTIVE"}},
+			{Name: "rules", Type: "Array", ItemType: &api.Type{Type: "Enum", EnumValues: []string{"ALLOW"}}},
+		},
+	}
+	beta := api.Resource{
+		Name:       "Widget",
+		UpdateMask: true,
+		Async:      &api.Async{Actions: []string{"create", "update"}},
+		IamPolicy:  &r.IamPolicy{},
+		Properties: []*api.Type{
+			{Name: "state", Type: "Enum", EnumValues: []string{"ACTIVE", "PAUSED"}},
+			{Name: "rules", Type: "Array", ItemType: &api.Type{Type: "Enum", EnumValues: []string{"ALLOW", "DENY"}}},
+		},
+	}
+
+	expected := []string{
+		"update is",
+		"update mask is",
+		"async update is",
+		"state enum values PAUSED are",
+		"rules[] enum values DENY are",
+	}
+	if diff := cmp.Diff(expected, versionDifferences(ga, &beta)); diff != "" {
+		t.Errorf("versionDifferences() diff (-expected +got):\n%s", diff)
+	}
+
+	merged := mergeVersion(ga, beta, "beta")
+	if merged.IamPolicy == nil || merged.IamPolicy.MinVersion != "beta" {
+		t.Errorf("Expected an IAM policy with min_version beta, found %+v", merged.IamPolicy)
+	}
+}
+
+// betaDiscovery returns the v1beta version of the test Discovery document,
+// which adds a Widget field, a WidgetConfig field and a Gizmo resource.
+func betaDiscovery(t *testing.T) []byte {
+	t.Helper()
+	data := strings.ReplaceAll(string(testDiscovery), `"v1/`, `"v1beta/`)
+	var d map[string]any
+	if err := json.Unmarshal([]byte(data), &d); err != nil {
+		t.Fatal(err)
+	}
+	d["version"] = "v1beta"
+
+	schemas := d["schemas"].(map[string]any)
+	addProperty := func(schema, name string) {
+		props := schemas[schema].(map[string]any)["properties"].(map[string]any)
+		props[name] = map[string]any{"type": "string", "description": "Optional. A beta field."}
+	}
+	addProperty("Widget", "color")
+	addProperty("WidgetConfig", "mode")
+
+	locations := d["resources"].(map[string]any)["projects"].(map[string]any)["resources"].(map[string]any)["locations"].(map[string]any)["resources"].(map[string]any)
+	gizmos, err := json.Marshal(locations["gadgets"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	gizmosData := strings.NewReplacer("Gadget", "Gizmo", "gadget", "gizmo").Replace(string(gizmos))
+	var gizmosResource any
+	if err := json.Unmarshal([]byte(gizmosData), &gizmosResource); err != nil {
+		t.Fatal(err)
+	}
+	locations["gizmos"] = gizmosResource
+	schemas["Gizmo"] = schemas["Gadget"]
+	schemas["ListGizmosResponse"] = schemas["ListGadgetsResponse"]
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestWriteYamlVersions(t *testing.T) {
+	input := t.TempDir()
+	ga := filepath.Join(input, "widgets_v1.discovery.json")
+	beta := filepath.Join(input, "widgets_v1beta.discovery.json")
+	if err := os.WriteFile(ga, testDiscovery, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(beta, betaDiscovery(t), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output := t.TempDir()
+	parser := Parser{Output: output}
+	if err := parser.WriteYaml(beta, ga); err != nil {
+		t.Fatal(err)
+	}
+
+	product := &api.Product{}
+	if err := api.Compile(filepath.Join(output, "widgets", "product.yaml"), product); err != nil {
+		t.Fatalf("Could not compile product.yaml: %s", err)
+	}
+	var versions []string
+	for _, v := range product.Versions {
+		versions = append(versions, v.Name+" "+v.BaseUrl)
+	}
+	expectedVersions := []string{"ga https://widgets.googleapis.com/v1/", "beta https://widgets.googleapis.com/v1beta/"}
+	if diff := cmp.Diff(expectedVersions, versions); diff != "" {
+		t.Errorf("product versions diff (-expected +got):\n%s", diff)
+	}
+
+	resources := make(map[string]*api.Resource)
+	for _, name := range []string{"Widget", "Gadget", "Gizmo", "Settings"} {
+		resource := &api.Resource{}
+		if err := api.Compile(filepath.Join(output, "widgets", name+".yaml"), resource); err != nil {
+			t.Fatalf("Could not compile %s.yaml: %s", name, err)
+		}
+		resources[name] = resource
+	}
+
+	if got := resources["Widget"].MinVersion; got != "" {
+		t.Errorf("Expected Widget to be in ga, found min_version %q", got)
+	}
+	if got := resources["Gizmo"].MinVersion; got != "beta" {
+		t.Errorf("Expected Gizmo min_version beta, found %q", got)
+	}
+	if got := resources["Widget"].BaseUrl; got != "projects/{{project}}/locations/{{location}}/widgets" {
+		t.Errorf("Expected the Widget base URL to have no version, found %q", got)
+	}
+
+	props := make(map[string]*api.Type)
+	for _, p := range resources["Widget"].Properties {
+		props[p.Name] = p
+	}
+	if props["color"] == nil || props["color"].MinVersion != "beta" {
+		t.Errorf("Expected Widget color with min_version beta, found %+v", props["color"])
+	}
+	if props["displayName"] == nil || props["displayName"].MinVersion != "" {
+		t.Errorf("Expected Widget displayName in ga, found %+v", props["displayName"])
+	}
+	var mode *api.Type
+	for _, p := range props["config"].Properties {
+		if p.Name == "mode" {
+			mode = p
+		}
+	}
+	if mode == nil || mode.MinVersion != "beta" {
+		t.Errorf("Expected Widget config.mode with min_version beta, found %+v", mode)
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"gopkg.in/yaml.v3"
+)
+
+// GenerationCache remembers, for each generated resource, a hash of its
+// inputs and of the files it produced. Resources whose inputs and outputs are
+// unchanged since the previous run can then be skipped.
+//
+// The inputs of a resource are its merged YAML definition, the product it
+// belongs to, the example configurations loaded for it and every template or
+// custom code file read while generating it.
+type GenerationCache struct {
+	path  string
+	force bool
+
+	mu        sync.Mutex
+	data      cacheData
+	decisions []CacheDecision
+}
+
+type cacheData struct {
+	// Hash of the generator binary. Any change to the generator invalidates
+	// every entry, as it may change the output of any template.
+	Generator string                 `json:"generator"`
+	Entries   map[string]*CacheEntry `json:"entries"`
+}
+
+// CacheEntry is the cached state of a single generated resource.
+type CacheEntry struct {
+	InputHash string `json:"input_hash"`
+	// Template and custom code files read during generation, keyed by their
+	// path in the template FS, with their content hashes.
+	Templates map[string]string `json:"templates"`
+	// Files written during generation, with their content hashes.
+	Outputs map[string]string `json:"outputs"`
+}
+
+// CacheDecision records whether a resource was regenerated and why.
+type CacheDecision struct {
+	Resource    string
+	Regenerated bool
+	Reason      string
+}
+
+// LoadGenerationCache reads the cache stored at path. A missing or unreadable
+// cache file results in an empty cache. If force is true every resource is
+// regenerated, but the cache is still updated.
+func LoadGenerationCache(path string, force bool) (*GenerationCache, error) {
+	c := &GenerationCache{
+		path:  path,
+		force: force,
+		data:  cacheData{Entries: make(map[string]*CacheEntry)},
+	}
+
+	generator, err := generatorHash()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(b, &c.data); err != nil || c.data.Entries == nil {
+			c.data.Entries = make(map[string]*CacheEntry)
+		}
+	}
+	if c.data.Generator != generator {
+		c.data = cacheData{Generator: generator, Entries: make(map[string]*CacheEntry)}
+	}
+	return c, nil
+}
+
+// Save writes the cache back to the path it was loaded from.
+func (c *GenerationCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := json.MarshalIndent(c.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, b, 0644)
+}
+
+// Decisions returns what happened to each resource checked against the
+// cache, sorted by resource.
+func (c *GenerationCache) Decisions() []CacheDecision {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	decisions := append([]CacheDecision(nil), c.decisions...)
+	sort.SliceStable(decisions, func(i, j int) bool {
+		return decisions[i].Resource < decisions[j].Resource
+	})
+	return decisions
+}
+
+// upToDate reports whether the resource identified by key can be skipped,
+// and records the decision. The reason explains why regeneration is needed.
+func (c *GenerationCache) upToDate(key, inputHash string, templateFS fs.FS) bool {
+	c.mu.Lock()
+	entry := c.data.Entries[key]
+	c.mu.Unlock()
+
+	reason := ""
+	switch {
+	case c.force:
+		reason = "forced"
+	case entry == nil:
+		reason = "not in cache"
+	case entry.InputHash != inputHash:
+		reason = "resource definition changed"
+	default:
+		reason = staleFile("template", entry.Templates, func(name string) ([]byte, error) {
+			return fs.ReadFile(templateFS, name)
+		})
+		if reason == "" {
+			reason = staleFile("output", entry.Outputs, os.ReadFile)
+		}
+	}
+
+	c.mu.Lock()
+	c.decisions = append(c.decisions, CacheDecision{Resource: key, Regenerated: reason != "", Reason: reason})
+	c.mu.Unlock()
+	return reason == ""
+}
+
+// staleFile returns a reason if any of the files no longer has the recorded
+// hash, or an empty string if all of them match.
+func staleFile(kind string, hashes map[string]string, read func(string) ([]byte, error)) string {
+	names := make([]string, 0, len(hashes))
+	for name := range hashes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b, err := read(name)
+		if err != nil {
+			return fmt.Sprintf("%s %s is missing", kind, name)
+		}
+		if hashBytes(b) != hashes[name] {
+			return fmt.Sprintf("%s %s changed", kind, name)
+		}
+	}
+	return ""
+}
+
+// store records the inputs and outputs of a freshly generated resource.
+func (c *GenerationCache) store(key, inputHash string, templateFS fs.FS, templates, outputs []string) {
+	entry := &CacheEntry{
+		InputHash: inputHash,
+		Templates: make(map[string]string),
+		Outputs:   make(map[string]string),
+	}
+	for _, name := range templates {
+		if b, err := fs.ReadFile(templateFS, name); err == nil {
+			entry.Templates[name] = hashBytes(b)
+		}
+	}
+	for _, name := range outputs {
+		if b, err := os.ReadFile(name); err == nil {
+			entry.Outputs[name] = hashBytes(b)
+		}
+	}
+
+	c.mu.Lock()
+	c.data.Entries[key] = entry
+	c.mu.Unlock()
+}
+
+// resourceInputHash hashes everything about a resource that is known before
+// any template is read.
+func resourceInputHash(object api.Resource, p *api.Product, generateCode, generateDocs bool) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "code=%t docs=%t version=%s import=%s\n", generateCode, generateDocs, object.TargetVersionName, object.ImportPath)
+
+	productCopy := *p
+	productCopy.Objects = nil
+	for _, v := range []interface{}{productCopy, p.Version, object} {
+		b, err := yaml.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		h.Write(b)
+	}
+
+	// Example configurations are loaded from template files at load time, so
+	// they aren't part of the marshalled resource.
+	for _, e := range object.Examples {
+		io.WriteString(h, e.DocumentationHCLText)
+		io.WriteString(h, e.TestHCLText)
+		io.WriteString(h, e.OicsHCLText)
+	}
+	for _, s := range object.Samples {
+		for _, step := range s.Steps {
+			io.WriteString(h, step.DocumentationHCLText)
+			io.WriteString(h, step.TestHCLText)
+			io.WriteString(h, step.OicsHCLText)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func generatorHash() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(exe)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashBytes(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// recordingFS wraps the template FS and records the regular files read
+// through it, so the cache knows which templates a resource depends on.
+type recordingFS struct {
+	fs.FS
+	mu    sync.Mutex
+	files map[string]bool
+}
+
+func newRecordingFS(fsys fs.FS) *recordingFS {
+	return &recordingFS{FS: fsys, files: make(map[string]bool)}
+}
+
+func (r *recordingFS) record(name string) {
+	r.mu.Lock()
+	r.files[name] = true
+	r.mu.Unlock()
+}
+
+// Open implements the main FS interface.
+func (r *recordingFS) Open(name string) (fs.File, error) {
+	f, err := r.FS.Open(name)
+	if err == nil {
+		if info, err := f.Stat(); err == nil && !info.IsDir() {
+			r.record(name)
+		}
+	}
+	return f, err
+}
+
+// ReadFile implements the ReadFileFS interface.
+func (r *recordingFS) ReadFile(name string) ([]byte, error) {
+	b, err := fs.ReadFile(r.FS, name)
+	if err == nil {
+		r.record(name)
+	}
+	return b, err
+}
+
+// ReadDir implements the ReadDirFS interface.
+func (r *recordingFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(r.FS, name)
+}
+
+// Files returns the recorded file names, sorted.
+func (r *recordingFS) Files() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	files := make([]string, 0, len(r.files))
+	for name := range r.files {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestGenerationCacheUpToDate(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "resource_foo.go")
+	if err := os.WriteFile(output, []byte("package foo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	templates := fstest.MapFS{
+		"templates/terraform/resource.go.tmpl": {Data: []byte("{{.Name}}")},
+	}
+
+	cache, err := LoadGenerationCache(filepath.Join(dir, "cache.json"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cache.upToDate("foo/Bar", "hash", templates) {
+		t.Fatal("expected an empty cache to require generation")
+	}
+	cache.store("foo/Bar", "hash", templates, []string{"templates/terraform/resource.go.tmpl"}, []string{output})
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err = LoadGenerationCache(filepath.Join(dir, "cache.json"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cache.upToDate("foo/Bar", "hash", templates) {
+		t.Error("expected unchanged resource to be up to date")
+	}
+	if cache.upToDate("foo/Bar", "other", templates) {
+		t.Error("expected changed resource definition to require generation")
+	}
+
+	changedTemplates := fstest.MapFS{
+		"templates/terraform/resource.go.tmpl": {Data: []byte("{{.Name}} changed")},
+	}
+	if cache.upToDate("foo/Bar", "hash", changedTemplates) {
+		t.Error("expected changed template to require generation")
+	}
+
+	if err := os.WriteFile(output, []byte("package foo // edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cache.upToDate("foo/Bar", "hash", templates) {
+		t.Error("expected edited output to require generation")
+	}
+
+	want := []CacheDecision{
+		{Resource: "foo/Bar", Regenerated: false},
+		{Resource: "foo/Bar", Regenerated: true, Reason: "resource definition changed"},
+		{Resource: "foo/Bar", Regenerated: true, Reason: "template templates/terraform/resource.go.tmpl changed"},
+		{Resource: "foo/Bar", Regenerated: true, Reason: "output " + output + " changed"},
+	}
+	got := cache.Decisions()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d decisions, got %v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("decision %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	forced, err := LoadGenerationCache(filepath.Join(dir, "cache.json"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forced.upToDate("foo/Bar", "hash", templates) {
+		t.Error("expected force to require generation")
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+const (
+	StatusAdded     = "added"
+	StatusChanged   = "changed"
+	StatusUnchanged = "unchanged"
+	StatusDeleted   = "deleted"
+)
+
+// DryRun collects generated files in memory instead of writing them to disk,
+// so that they can be compared against an existing output tree.
+type DryRun struct {
+	*google.MemoryOutputFS
+
+	// ReportDeleted also reports the files in the output tree that weren't
+	// generated. It should only be set when every file is generated.
+	ReportDeleted bool
+}
+
+// ManifestEntry describes what generation would do to a single file, relative
+// to the output folder.
+type ManifestEntry struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+}
+
+func NewDryRun() *DryRun {
+	return &DryRun{MemoryOutputFS: google.NewMemoryOutputFS()}
+}
+
+// Manifest compares every file written during the dry run with the file at
+// the same path on disk. Entries are sorted by path.
+func (d *DryRun) Manifest(outputFolder string) ([]ManifestEntry, error) {
+	var manifest []ManifestEntry
+	err := d.compare(outputFolder, func(c fileChange) error {
+		manifest = append(manifest, ManifestEntry{Path: c.path, Status: c.status()})
+		return nil
+	})
+	return manifest, err
+}
+
+// WriteDiff writes a unified diff between the output tree on disk and the
+// files written during the dry run.
+func (d *DryRun) WriteDiff(w io.Writer, outputFolder string) error {
+	return d.compare(outputFolder, func(c fileChange) error {
+		oldName, newName := "a/"+filepath.ToSlash(c.path), "b/"+filepath.ToSlash(c.path)
+		if !c.exists {
+			oldName = "/dev/null"
+		}
+		if !c.generated {
+			newName = "/dev/null"
+		}
+		_, err := io.WriteString(w, unifiedDiff(oldName, newName, c.before, c.after))
+		return err
+	})
+}
+
+// fileChange is a file in the output tree, the dry run, or both.
+type fileChange struct {
+	path              string
+	before, after     []byte
+	exists, generated bool
+}
+
+func (c fileChange) status() string {
+	switch {
+	case !c.exists:
+		return StatusAdded
+	case !c.generated:
+		return StatusDeleted
+	case !bytes.Equal(c.before, c.after):
+		return StatusChanged
+	}
+	return StatusUnchanged
+}
+
+// compare calls f for each file written during the dry run, and each file
+// that would be deleted if ReportDeleted is set, in path order.
+func (d *DryRun) compare(outputFolder string, f func(c fileChange) error) error {
+	changes := make(map[string]*fileChange)
+	for _, name := range d.Files() {
+		rel, err := filepath.Rel(outputFolder, name)
+		if err != nil {
+			return err
+		}
+		before, err := os.ReadFile(name)
+		exists := err == nil
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		after, err := d.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		changes[rel] = &fileChange{path: rel, before: before, after: after, exists: exists, generated: true}
+	}
+
+	if d.ReportDeleted {
+		if err := d.addDeleted(outputFolder, changes); err != nil {
+			return err
+		}
+	}
+
+	for _, rel := range slices.Sorted(maps.Keys(changes)) {
+		if err := f(*changes[rel]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keptFiles matches the slash-separated paths, relative to the output folder,
+// that clean-provider in the GNUmakefile keeps. Keep the two in sync.
+var keptFiles = regexp.MustCompile(`(^\.git|^\.changelog|^\.travis\.yml$|^\.golangci\.yml$|^CHANGELOG\.md$|^CHANGELOG_v.*\.md$|^GNUmakefile$|docscheck\.sh$|^LICENSE$|^CODEOWNERS$|^README\.md$|^\.go-version$|^\.hashibot\.hcl$|^go\.mod$|^go\.sum$|^examples|^scripts/)`)
+
+// dclMarker is in the header of every file generated by tpgtools.
+var dclMarker = []byte("***    Type: DCL     ***")
+
+// addDeleted adds the files under outputFolder that weren't generated and
+// that a clean build would remove to changes. Hidden files and directories,
+// files clean-provider keeps and files generated by tpgtools are skipped.
+func (d *DryRun) addDeleted(outputFolder string, changes map[string]*fileChange) error {
+	err := filepath.WalkDir(outputFolder, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name != outputFolder && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(outputFolder, name)
+		if err != nil {
+			return err
+		}
+		if _, ok := changes[rel]; ok || keptFiles.MatchString(filepath.ToSlash(rel)) {
+			return nil
+		}
+		before, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if bytes.Contains(before, dclMarker) {
+			return nil
+		}
+		changes[rel] = &fileChange{path: rel, before: before, exists: true}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDryRunManifest(t *testing.T) {
+	outputFolder := t.TempDir()
+	for name, content := range map[string]string{
+		"same.go":    "package a\n",
+		"changed.go": "package a\n\nvar x = 1\n",
+	} {
+		if err := os.WriteFile(filepath.Join(outputFolder, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewDryRun()
+	for name, content := range map[string]string{
+		"same.go":       "package a\n",
+		"changed.go":    "package a\n\nvar x = 2\n",
+		"sub/added.go":  "package sub\n",
+		"sub/../dup.go": "package a\n",
+	} {
+		if err := d.WriteFile(filepath.Join(outputFolder, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifest, err := d.Manifest(outputFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ManifestEntry{
+		{Path: "changed.go", Status: StatusChanged},
+		{Path: "dup.go", Status: StatusAdded},
+		{Path: "same.go", Status: StatusUnchanged},
+		{Path: filepath.Join("sub", "added.go"), Status: StatusAdded},
+	}
+	if diff := cmp.Diff(want, manifest); diff != "" {
+		t.Errorf("Manifest() mismatch (-want +got):\n%s", diff)
+	}
+
+	var diff bytes.Buffer
+	if err := d.WriteDiff(&diff, outputFolder); err != nil {
+		t.Fatal(err)
+	}
+	wantDiff := "--- a/changed.go\n" +
+		"+++ b/changed.go\n" +
+		"@@ -1,3 +1,3 @@\n" +
+		" package a\n" +
+		" \n" +
+		"-var x = 1\n" +
+		"+var x = 2\n" +
+		`--- /dev/null
++++ b/dup.go
+@@ -0,0 +1 @@
++package a
+--- /dev/null
++++ b/sub/added.go
+@@ -0,0 +1 @@
++package sub
+`
+	if got := diff.String(); got != wantDiff {
+		t.Errorf("WriteDiff() mismatch (-want +got):\n%s", cmp.Diff(wantDiff, got))
+	}
+
+	// Nothing is written to disk.
+	if _, err := os.Stat(filepath.Join(outputFolder, "dup.go")); !os.IsNotExist(err) {
+		t.Errorf("dup.go was written to disk")
+	}
+}
+
+func TestDryRunManifestDeleted(t *testing.T) {
+	outputFolder := t.TempDir()
+	for name, content := range map[string]string{
+		"kept.go":        "package a\n",
+		"removed.go":     "package a\n",
+		"sub/old.go":     "package sub\n",
+		".git/HEAD":      "ref: refs/heads/main\n",
+		".gitignore":     "*.log\n",
+		"docs/widget.md": "# Widget\n",
+		// Kept by clean-provider
+		"CHANGELOG.md":         "## 1.0.0\n",
+		"examples/main.tf":     "# example\n",
+		"scripts/docscheck.sh": "#!/bin/sh\n",
+		// Generated by tpgtools
+		"services/dcl/resource_dcl.go": "//     ***     AUTO GENERATED CODE    ***    Type: DCL     ***\npackage dcl\n",
+	} {
+		path := filepath.Join(outputFolder, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewDryRun()
+	d.ReportDeleted = true
+	for name, content := range map[string]string{
+		"kept.go":        "package a\n",
+		"docs/widget.md": "# Widget\n",
+	} {
+		if err := d.WriteFile(filepath.Join(outputFolder, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifest, err := d.Manifest(outputFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ManifestEntry{
+		{Path: filepath.Join("docs", "widget.md"), Status: StatusUnchanged},
+		{Path: "kept.go", Status: StatusUnchanged},
+		{Path: "removed.go", Status: StatusDeleted},
+		{Path: filepath.Join("sub", "old.go"), Status: StatusDeleted},
+	}
+	if diff := cmp.Diff(want, manifest); diff != "" {
+		t.Errorf("Manifest() mismatch (-want +got):\n%s", diff)
+	}
+
+	var diff bytes.Buffer
+	if err := d.WriteDiff(&diff, outputFolder); err != nil {
+		t.Fatal(err)
+	}
+	wantDiff := `--- a/removed.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package a
+--- a/sub/old.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package sub
+`
+	if got := diff.String(); got != wantDiff {
+		t.Errorf("WriteDiff() mismatch (-want +got):\n%s", cmp.Diff(wantDiff, got))
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name          string
+		before, after string
+		want          string
+	}{
+		{
+			name:   "equal",
+			before: "a\nb\n",
+			after:  "a\nb\n",
+			want:   "",
+		},
+		{
+			name:   "separate hunks",
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			after:  "1\nx\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			want: `--- a
++++ b
+@@ -1,5 +1,5 @@
+ 1
+-2
++x
+ 3
+ 4
+ 5
+@@ -9,4 +9,3 @@
+ 9
+ 10
+ 11
+-12
+`,
+		},
+		{
+			name:   "merged hunks",
+			before: "1\n2\n3\n4\n5\n6\n",
+			after:  "0\n1\n2\n3\n4\n6\n",
+			want: `--- a
++++ b
+@@ -1,6 +1,6 @@
++0
+ 1
+ 2
+ 3
+ 4
+-5
+ 6
+`,
+		},
+		{
+			name:   "no trailing newline",
+			before: "a\nb",
+			after:  "a\nc",
+			want: `--- a
++++ b
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++c
+\ No newline at end of file
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := unifiedDiff("a", "b", []byte(tc.before), []byte(tc.after)); got != tc.want {
+				t.Errorf("unifiedDiff() mismatch (-want +got):\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+// clean-provider is the source of truth for the files a clean build keeps.
+func TestKeptFilesMatchCleanProvider(t *testing.T) {
+	makefile, err := os.ReadFile(filepath.Join("..", "..", "GNUmakefile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// make escapes $ as $$.
+	pattern := strings.ReplaceAll(keptFiles.String(), "$", "$$")
+	if !strings.Contains(string(makefile), "grep -v -E '"+pattern+"'") {
+		t.Errorf("keptFiles %s doesn't match the clean-provider exclusion list in GNUmakefile", keptFiles)
+	}
+}
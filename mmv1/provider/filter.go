@@ -0,0 +1,206 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+// ResourceFilter selects the products and resources to generate using
+// "product/resource" glob patterns, such as "compute/*Firewall*" or
+// "networksecurity/*". Products are named by their directory under products/
+// and resources by their name. Matching is case-insensitive.
+//
+// A nil filter matches everything.
+type ResourceFilter struct {
+	include []pattern
+	exclude []pattern
+}
+
+type pattern struct {
+	product, resource string
+}
+
+func (p pattern) String() string {
+	return p.product + "/" + p.resource
+}
+
+// NewResourceFilter builds a filter from the -product, -resource and
+// -exclude flag values.
+//
+// Products may be full "product/resource" patterns, or product patterns that
+// are combined with each of the resource patterns. Resource patterns without
+// a product apply to every selected product. If no products or resources are
+// given, every resource is included. Exclude patterns name a product, or a
+// "product/resource" pair, and take precedence over includes.
+func NewResourceFilter(products, resources, excludes []string) (*ResourceFilter, error) {
+	f := &ResourceFilter{}
+
+	var resourceNames []string
+	for _, r := range resources {
+		if strings.Contains(r, "/") {
+			p, err := parsePattern(r)
+			if err != nil {
+				return nil, err
+			}
+			f.include = append(f.include, p)
+		} else {
+			resourceNames = append(resourceNames, r)
+		}
+	}
+
+	for _, p := range products {
+		if strings.Contains(p, "/") || len(resourceNames) == 0 {
+			pat, err := parsePattern(p)
+			if err != nil {
+				return nil, err
+			}
+			f.include = append(f.include, pat)
+			continue
+		}
+		for _, r := range resourceNames {
+			pat, err := parsePattern(p + "/" + r)
+			if err != nil {
+				return nil, err
+			}
+			f.include = append(f.include, pat)
+		}
+	}
+	if len(products) == 0 {
+		for _, r := range resourceNames {
+			pat, err := parsePattern("*/" + r)
+			if err != nil {
+				return nil, err
+			}
+			f.include = append(f.include, pat)
+		}
+	}
+
+	for _, e := range excludes {
+		pat, err := parsePattern(e)
+		if err != nil {
+			return nil, err
+		}
+		f.exclude = append(f.exclude, pat)
+	}
+	return f, nil
+}
+
+// parsePattern parses "product" or "product/resource". A missing resource
+// matches every resource in the product.
+func parsePattern(s string) (pattern, error) {
+	product, resource, found := strings.Cut(strings.ToLower(strings.TrimSpace(s)), "/")
+	if !found || resource == "" {
+		resource = "*"
+	}
+	p := pattern{product: product, resource: resource}
+	if product == "" || strings.Contains(resource, "/") {
+		return p, fmt.Errorf("invalid pattern %q, expected product or product/resource", s)
+	}
+	for _, glob := range []string{product, resource} {
+		if _, err := path.Match(glob, ""); err != nil {
+			return p, fmt.Errorf("invalid pattern %q: %w", s, err)
+		}
+	}
+	return p, nil
+}
+
+// ProductName returns the name a product is matched by: its directory under
+// products/.
+func ProductName(p *api.Product) string {
+	return path.Base(p.PackagePath)
+}
+
+// MatchesProduct reports whether any resource of the product may be
+// generated.
+func (f *ResourceFilter) MatchesProduct(p *api.Product) bool {
+	if f == nil {
+		return true
+	}
+	name := strings.ToLower(ProductName(p))
+	for _, e := range f.exclude {
+		if e.resource == "*" && match(e.product, name) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, i := range f.include {
+		if match(i.product, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether the resource of the product should be generated.
+func (f *ResourceFilter) Matches(p *api.Product, r *api.Resource) bool {
+	if f == nil {
+		return true
+	}
+	productName, resourceName := strings.ToLower(ProductName(p)), strings.ToLower(r.Name)
+	for _, e := range f.exclude {
+		if match(e.product, productName) && match(e.resource, resourceName) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, i := range f.include {
+		if match(i.product, productName) && match(i.resource, resourceName) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesAll reports whether the filter includes every resource.
+func (f *ResourceFilter) MatchesAll() bool {
+	return f == nil || (len(f.include) == 0 && len(f.exclude) == 0)
+}
+
+// String describes the filter for logging.
+func (f *ResourceFilter) String() string {
+	if f.MatchesAll() {
+		return "all resources"
+	}
+	var parts []string
+	if len(f.include) > 0 {
+		parts = append(parts, "including "+joinPatterns(f.include))
+	}
+	if len(f.exclude) > 0 {
+		parts = append(parts, "excluding "+joinPatterns(f.exclude))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func joinPatterns(patterns []pattern) string {
+	var s []string
+	for _, p := range patterns {
+		s = append(s, p.String())
+	}
+	return strings.Join(s, ", ")
+}
+
+func match(glob, name string) bool {
+	// Patterns are validated when parsed, so Match can't fail.
+	ok, _ := path.Match(glob, name)
+	return ok
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func TestResourceFilter(t *testing.T) {
+	t.Parallel()
+
+	compute := &api.Product{Name: "Compute", PackagePath: "products/compute"}
+	networkSecurity := &api.Product{Name: "NetworkSecurity", PackagePath: "products/networksecurity"}
+	pubsub := &api.Product{Name: "Pubsub", PackagePath: "products/pubsub"}
+
+	type resource struct {
+		product *api.Product
+		name    string
+	}
+	firewall := resource{compute, "Firewall"}
+	firewallPolicy := resource{compute, "RegionNetworkFirewallPolicy"}
+	instance := resource{compute, "Instance"}
+	authzPolicy := resource{networkSecurity, "AuthzPolicy"}
+	addressGroup := resource{networkSecurity, "AddressGroup"}
+	topic := resource{pubsub, "Topic"}
+	all := []resource{firewall, firewallPolicy, instance, authzPolicy, addressGroup, topic}
+
+	cases := []struct {
+		name                         string
+		products, resources, exclude []string
+		want                         []resource
+		wantProducts                 []*api.Product
+	}{
+		{
+			name:         "no filters",
+			want:         all,
+			wantProducts: []*api.Product{compute, networkSecurity, pubsub},
+		},
+		{
+			name:         "single product and resource",
+			products:     []string{"pubsub"},
+			resources:    []string{"Topic"},
+			want:         []resource{topic},
+			wantProducts: []*api.Product{pubsub},
+		},
+		{
+			name:         "product/resource globs",
+			products:     []string{"compute/*firewall*", "networksecurity"},
+			want:         []resource{firewall, firewallPolicy, authzPolicy, addressGroup},
+			wantProducts: []*api.Product{compute, networkSecurity},
+		},
+		{
+			name:         "resource globs across products",
+			resources:    []string{"*Policy"},
+			want:         []resource{firewallPolicy, authzPolicy},
+			wantProducts: []*api.Product{compute, networkSecurity, pubsub},
+		},
+		{
+			name:         "excludes take precedence",
+			products:     []string{"compute", "networksecurity"},
+			exclude:      []string{"compute/instance", "networksecurity/Authz*"},
+			want:         []resource{firewall, firewallPolicy, addressGroup},
+			wantProducts: []*api.Product{compute, networkSecurity},
+		},
+		{
+			name:         "excluded product",
+			exclude:      []string{"compute"},
+			want:         []resource{authzPolicy, addressGroup, topic},
+			wantProducts: []*api.Product{networkSecurity, pubsub},
+		},
+		{
+			name:     "nonexistent product",
+			products: []string{"doesnotexist"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := NewResourceFilter(tc.products, tc.resources, tc.exclude)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := make(map[resource]bool)
+			for _, r := range tc.want {
+				want[r] = true
+			}
+			for _, r := range all {
+				if got := f.Matches(r.product, &api.Resource{Name: r.name}); got != want[r] {
+					t.Errorf("Matches(%s, %s) = %t, want %t", r.product.Name, r.name, got, want[r])
+				}
+			}
+			wantProducts := make(map[*api.Product]bool)
+			for _, p := range tc.wantProducts {
+				wantProducts[p] = true
+			}
+			for _, p := range []*api.Product{compute, networkSecurity, pubsub} {
+				if got := f.MatchesProduct(p); got != wantProducts[p] {
+					t.Errorf("MatchesProduct(%s) = %t, want %t", p.Name, got, wantProducts[p])
+				}
+			}
+		})
+	}
+}
+
+func TestResourceFilterInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	for _, p := range []string{"/Topic", "compute/[", "a/b/c"} {
+		if _, err := NewResourceFilter([]string{p}, nil, nil); err == nil {
+			t.Errorf("NewResourceFilter(%q) returned no error", p)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// Reasons a product or resource was not generated.
+const (
+	SkipExcluded     = "excluded"
+	SkipNotInVersion = "not in version"
+	SkipFiltered     = "filtered"
+	SkipUnchanged    = "unchanged"
+)
+
+// GenerationReport is a machine-readable summary of a generation run: which
+// products and resources were generated or skipped and why, the files
+// written, the templates used and how long each resource took.
+//
+// All methods are safe to call on a nil report, and do nothing.
+type GenerationReport struct {
+	Version     string           `json:"version"`
+	Provider    string           `json:"provider"`
+	StartTime   time.Time        `json:"start_time"`
+	DurationMs  int64            `json:"duration_ms"`
+	Products    []*ProductReport `json:"products"`
+	CommonFiles []string         `json:"common_files,omitempty"`
+
+	outputFolder string
+	mu           sync.Mutex
+	products     map[string]*ProductReport
+}
+
+type ProductReport struct {
+	Name       string            `json:"name"`
+	Generated  bool              `json:"generated"`
+	SkipReason string            `json:"skip_reason,omitempty"`
+	DurationMs int64             `json:"duration_ms,omitempty"`
+	Resources  []*ResourceReport `json:"resources,omitempty"`
+	// All files written for the product, including those of its resources.
+	Files []string `json:"files,omitempty"`
+}
+
+type ResourceReport struct {
+	Name       string `json:"name"`
+	Generated  bool   `json:"generated"`
+	SkipReason string `json:"skip_reason,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	// Files written for the resource. Excluded resources may still have an
+	// IAM policy generated.
+	Files     []string `json:"files,omitempty"`
+	Templates []string `json:"templates,omitempty"`
+}
+
+// NewGenerationReport creates an empty report. File paths are recorded
+// relative to outputFolder.
+func NewGenerationReport(outputFolder string) *GenerationReport {
+	return &GenerationReport{
+		outputFolder: outputFolder,
+		products:     make(map[string]*ProductReport),
+	}
+}
+
+// product returns the report for the named product, creating it if needed.
+// The caller must hold r.mu.
+func (r *GenerationReport) product(name string) *ProductReport {
+	p, ok := r.products[name]
+	if !ok {
+		p = &ProductReport{Name: name}
+		r.products[name] = p
+	}
+	return p
+}
+
+// SkipProduct records that a product was not generated.
+func (r *GenerationReport) SkipProduct(name, reason string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := r.product(name)
+	p.SkipReason = reason
+}
+
+// GeneratedProduct records that a product was generated, and how long it
+// took.
+func (r *GenerationReport) GeneratedProduct(p *api.Product, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pr := r.product(ProductName(p))
+	pr.Generated = true
+	pr.DurationMs = duration.Milliseconds()
+}
+
+func (r *GenerationReport) addProductFiles(p *api.Product, files []string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pr := r.product(ProductName(p))
+	pr.Files = append(pr.Files, r.relativePaths(files)...)
+}
+
+func (r *GenerationReport) addCommonFiles(files []string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.CommonFiles = append(r.CommonFiles, r.relativePaths(files)...)
+}
+
+// skipResource records that a resource was not visited at all.
+func (r *GenerationReport) skipResource(p *api.Product, name, reason string) {
+	r.addResource(p, &ResourceReport{Name: name, SkipReason: reason})
+}
+
+// generatedResource records the outcome of generating a resource. A resource
+// that is excluded, or not in the product version, is still reported as
+// skipped even though its IAM policy may have been generated.
+func (r *GenerationReport) generatedResource(p *api.Product, object api.Resource, files, templates []string, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	reason := resourceSkipReason(object, p.Version)
+	r.addResource(p, &ResourceReport{
+		Name:       object.Name,
+		Generated:  reason == "",
+		SkipReason: reason,
+		DurationMs: duration.Milliseconds(),
+		Files:      r.relativePaths(files),
+		Templates:  templates,
+	})
+}
+
+func (r *GenerationReport) addResource(p *api.Product, rr *ResourceReport) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pr := r.product(ProductName(p))
+	pr.Resources = append(pr.Resources, rr)
+}
+
+// resourceSkipReason explains why a resource that went through
+// ExcludeIfNotInVersion is excluded, or returns an empty string if it isn't.
+func resourceSkipReason(object api.Resource, version *product.Version) string {
+	switch {
+	case !object.IsExcluded():
+		return ""
+	case version != nil && object.NotInVersion(version):
+		return SkipNotInVersion
+	default:
+		return SkipExcluded
+	}
+}
+
+// relativePaths returns files relative to the output folder, sorted.
+func (r *GenerationReport) relativePaths(files []string) []string {
+	var paths []string
+	for _, f := range files {
+		if rel, err := filepath.Rel(r.outputFolder, f); err == nil {
+			f = filepath.ToSlash(rel)
+		}
+		paths = append(paths, f)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Write sorts the report, records the total duration since StartTime and
+// writes it to path as JSON.
+func (r *GenerationReport) Write(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.DurationMs = time.Since(r.StartTime).Milliseconds()
+	r.Products = r.Products[:0]
+	for _, p := range r.products {
+		sort.Slice(p.Resources, func(i, j int) bool {
+			return p.Resources[i].Name < p.Resources[j].Name
+		})
+		sort.Strings(p.Files)
+		r.Products = append(r.Products, p)
+	}
+	sort.Slice(r.Products, func(i, j int) bool {
+		return strings.ToLower(r.Products[i].Name) < strings.ToLower(r.Products[j].Name)
+	})
+	sort.Strings(r.CommonFiles)
+
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0644)
+}
+
+// recordingOutputFS wraps an output FS and records the files written through
+// it.
+type recordingOutputFS struct {
+	google.OutputFS
+	mu    sync.Mutex
+	files []string
+}
+
+func newRecordingOutputFS(output google.OutputFS) *recordingOutputFS {
+	return &recordingOutputFS{OutputFS: output}
+}
+
+func (r *recordingOutputFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if err := r.OutputFS.WriteFile(name, data, perm); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.files = append(r.files, name)
+	r.mu.Unlock()
+	return nil
+}
+
+// Files returns the distinct files written.
+func (r *recordingOutputFS) Files() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, f := range r.files {
+		if !seen[f] {
+			seen[f] = true
+			files = append(files, f)
+		}
+	}
+	return files
+}
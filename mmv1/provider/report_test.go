@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestGenerationReport(t *testing.T) {
+	ga := &product.Version{Name: "ga"}
+	pubsub := &api.Product{
+		Name:        "Pubsub",
+		PackagePath: "products/pubsub",
+		Versions:    []*product.Version{ga, {Name: "beta"}},
+		Version:     ga,
+	}
+
+	r := NewGenerationReport("out")
+	r.Version, r.Provider, r.StartTime = "ga", "terraform", time.Now()
+
+	r.SkipProduct("compute", SkipFiltered)
+	r.SkipProduct("betaonly", SkipNotInVersion)
+	r.GeneratedProduct(pubsub, 2*time.Second)
+	r.addProductFiles(pubsub, []string{"out/google/services/pubsub/product.go"})
+	r.skipResource(pubsub, "Snapshot", SkipFiltered)
+	r.generatedResource(pubsub, api.Resource{Name: "Topic", ProductMetadata: pubsub},
+		[]string{"out/google/services/pubsub/resource_pubsub_topic.go", "out/website/docs/r/pubsub_topic.html.markdown"},
+		[]string{"templates/terraform/resource.go.tmpl"}, 1500*time.Millisecond)
+	// Resources are marked as excluded by ExcludeIfNotInVersion before being
+	// generated.
+	r.generatedResource(pubsub, api.Resource{Name: "Schema", MinVersion: "beta", Exclude: true, ProductMetadata: pubsub}, nil, nil, 0)
+	r.generatedResource(pubsub, api.Resource{Name: "Lite", Exclude: true, ProductMetadata: pubsub}, nil, nil, 0)
+	r.addCommonFiles([]string{"out/main.go"})
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := r.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got GenerationReport
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &GenerationReport{
+		Version:  "ga",
+		Provider: "terraform",
+		Products: []*ProductReport{
+			{Name: "betaonly", SkipReason: SkipNotInVersion},
+			{Name: "compute", SkipReason: SkipFiltered},
+			{
+				Name:       "pubsub",
+				Generated:  true,
+				DurationMs: 2000,
+				Files:      []string{"google/services/pubsub/product.go"},
+				Resources: []*ResourceReport{
+					{Name: "Lite", SkipReason: SkipExcluded},
+					{Name: "Schema", SkipReason: SkipNotInVersion},
+					{Name: "Snapshot", SkipReason: SkipFiltered},
+					{
+						Name:       "Topic",
+						Generated:  true,
+						DurationMs: 1500,
+						Files:      []string{"google/services/pubsub/resource_pubsub_topic.go", "website/docs/r/pubsub_topic.html.markdown"},
+						Templates:  []string{"templates/terraform/resource.go.tmpl"},
+					},
+				},
+			},
+		},
+		CommonFiles: []string{"main.go"},
+	}
+	opts := cmp.Options{
+		cmpopts.IgnoreUnexported(GenerationReport{}),
+		cmpopts.IgnoreFields(GenerationReport{}, "StartTime", "DurationMs"),
+	}
+	if diff := cmp.Diff(want, &got, opts); diff != "" {
+		t.Errorf("report mismatch (-want +got):\n%s", diff)
+	}
+}
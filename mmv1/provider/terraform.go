@@ -14,7 +14,6 @@
 package provider
 
 import (
-	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
@@ -50,6 +49,17 @@ type Terraform struct {
 
 	StartTime time.Time
 
+	// If set, resources whose inputs and outputs are unchanged since the
+	// previous run are not regenerated.
+	Cache *GenerationCache
+
+	// If set, generated and skipped resources are recorded in it.
+	Report *GenerationReport
+
+	// Where generated files are written. Defaults to the output folder on
+	// disk when nil.
+	Output google.OutputFS
+
 	templateFS fs.FS
 }
 
@@ -72,12 +82,32 @@ func NewTerraform(product *api.Product, versionName string, startTime time.Time,
 	return t
 }
 
-func (t Terraform) Generate(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
-	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+// output returns where generated files are written.
+func (t Terraform) output() google.OutputFS {
+	if t.Output != nil {
+		return t.Output
+	}
+	return google.DirOutputFS{}
+}
+
+func (t Terraform) newTemplateData(outputFolder string, templateFS fs.FS) *TemplateData {
+	td := NewTemplateData(outputFolder, t.TargetVersionName, templateFS)
+	td.output = t.output()
+	return td
+}
+
+func (t Terraform) Generate(outputFolder string, filter *ResourceFilter, generateCode, generateDocs bool) {
+	if t.Report != nil {
+		output := newRecordingOutputFS(t.output())
+		t.Output = output
+		defer func() { t.Report.addProductFiles(t.Product, output.Files()) }()
+	}
+
+	if err := t.output().MkdirAll(outputFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
 	}
 
-	t.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
+	t.GenerateObjects(outputFolder, filter, generateCode, generateDocs)
 
 	if generateCode {
 		t.GenerateProduct(outputFolder)
@@ -85,12 +115,13 @@ func (t Terraform) Generate(outputFolder, resourceToGenerate string, generateCod
 	}
 }
 
-func (t *Terraform) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (t *Terraform) GenerateObjects(outputFolder string, filter *ResourceFilter, generateCode, generateDocs bool) {
 	for _, object := range t.Product.Objects {
 		object.ExcludeIfNotInVersion(t.Product.Version)
 
-		if resourceToGenerate != "" && object.Name != resourceToGenerate {
+		if !filter.Matches(t.Product, object) {
 			log.Printf("Excluding %s per user request", object.Name)
+			t.Report.skipResource(t.Product, object.Name, SkipFiltered)
 			continue
 		}
 
@@ -99,11 +130,55 @@ func (t *Terraform) GenerateObjects(outputFolder, resourceToGenerate string, gen
 }
 
 func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPath string, generateCode, generateDocs bool) {
-	templateData := NewTemplateData(outputFolder, t.TargetVersionName, t.templateFS)
+	if t.Cache == nil && t.Report == nil {
+		t.generateObject(object, outputFolder, t.templateFS, nil, generateCode, generateDocs)
+		return
+	}
+
+	start := time.Now()
+	key := fmt.Sprintf("%s/%s", t.Product.ApiName, object.Name)
+	var inputHash string
+	if t.Cache != nil {
+		var err error
+		inputHash, err = resourceInputHash(object, t.Product, generateCode, generateDocs)
+		if err != nil {
+			log.Printf("Cannot cache %s: %v", key, err)
+		} else if t.Cache.upToDate(key, inputHash, t.templateFS) {
+			log.Printf("Skipping %s resource, unchanged since the last run", object.Name)
+			reason := resourceSkipReason(object, t.Product.Version)
+			if reason == "" {
+				reason = SkipUnchanged
+			}
+			t.Report.skipResource(t.Product, object.Name, reason)
+			return
+		}
+	}
+
+	recordingFS := newRecordingFS(t.templateFS)
+	var written []string
+	t.generateObject(object, outputFolder, recordingFS, &written, generateCode, generateDocs)
+	if inputHash != "" {
+		t.Cache.store(key, inputHash, t.templateFS, recordingFS.Files(), written)
+	}
+	t.Report.generatedResource(t.Product, object, written, recordingFS.Files(), time.Since(start))
+}
+
+func (t *Terraform) generateObject(object api.Resource, outputFolder string, templateFS fs.FS, writtenFiles *[]string, generateCode, generateDocs bool) {
+	templateData := t.newTemplateData(outputFolder, templateFS)
+	templateData.writtenFiles = writtenFiles
+
+	if object.Ephemeral != nil {
+		if !object.IsExcluded() {
+			log.Printf("Generating %s ephemeral resource", object.Name)
+			t.GenerateEphemeralResource(object, *templateData, outputFolder, generateCode, generateDocs)
+		}
+		return
+	}
 
 	if !object.IsExcluded() {
 		log.Printf("Generating %s resource", object.Name)
 		t.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
+		t.GeneratePluralDataSource(object, *templateData, outputFolder, generateCode, generateDocs)
 
 		if generateCode {
 			// log.Printf("Generating %s tests", object.Name)
@@ -111,6 +186,7 @@ func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPat
 			t.GenerateResourceSweeper(object, *templateData, outputFolder)
 			t.GenerateSingularDataSource(object, *templateData, outputFolder)
 			t.GenerateSingularDataSourceTests(object, *templateData, outputFolder)
+			t.GeneratePluralDataSourceTests(object, *templateData, outputFolder)
 			// log.Printf("Generating %s metadata", object.Name)
 			t.GenerateResourceMetadata(object, *templateData, outputFolder)
 		}
@@ -128,25 +204,12 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 	if generateCode {
 		productName := t.Product.ApiName
 		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 		}
 		if object.FrameworkResource {
-			fmt.Printf("\n\x1b[1;33mWARNING:\x1b[0m\n")
-			fmt.Printf("The plugin framework generation code is considered a WIP and experimental.\nAre you sure you want to use it for %s? (y/n) ", t.ResourceGoFilename(object))
-
-			reader := bufio.NewReader(os.Stdin)
-			input, err := reader.ReadString('\n')
-			if err != nil {
-				log.Fatalf("Error reading input: %v", err)
-			}
-
-			if strings.ToLower(strings.TrimSpace(input)) == "y" {
-				targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_fw_%s.go", t.ResourceGoFilename(object)))
-				templateData.GenerateFWResourceFile(targetFilePath, object)
-			} else {
-				log.Fatalf("please remove \"plugin_framework_experimental: true\" from the YAML configuration.")
-			}
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_fw_%s.go", t.ResourceGoFilename(object)))
+			templateData.GenerateFWResourceFile(targetFilePath, object)
 		} else {
 			targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s.go", t.ResourceGoFilename(object)))
 			templateData.GenerateResourceFile(targetFilePath, object)
@@ -155,7 +218,7 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 
 	if generateDocs {
 		targetFolder := path.Join(outputFolder, "website", "docs", "r")
-		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
@@ -166,21 +229,50 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 // GenerateResourceFile is the Bazel counterpart to GenerateResource(), generating *only() the .go file and
 // taking the full path to the output file to generate rather than implicitly generating the path.
 func (t *Terraform) GenerateResourceFile(object api.Resource, targetFilePath string) {
-	if object.FrameworkResource {
-		log.Fatalf("Framework resources are currently unsupported")
-	}
 	targetFolder := path.Dir(targetFilePath)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
-	templateData := NewTemplateData("", t.TargetVersionName, t.templateFS)
+	templateData := t.newTemplateData("", t.templateFS)
+	if object.FrameworkResource {
+		templateData.GenerateFWResourceFile(targetFilePath, object)
+		return
+	}
 	templateData.GenerateResourceFile(targetFilePath, object)
 }
 
+// GenerateEphemeralResource generates a resource with `ephemeral` as a plugin
+// framework ephemeral resource, along with its tests and documentation.
+func (t *Terraform) GenerateEphemeralResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if generateCode {
+		productName := t.Product.ApiName
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+		if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("ephemeral_%s.go", t.ResourceGoFilename(object)))
+		templateData.GenerateEphemeralResourceFile(targetFilePath, object)
+
+		if len(object.TestExamples()) > 0 {
+			targetFilePath = path.Join(targetFolder, fmt.Sprintf("ephemeral_%s_generated_test.go", t.ResourceGoFilename(object)))
+			templateData.GenerateEphemeralResourceTestFile(targetFilePath, object)
+		}
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "ephemeral-resources")
+		if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
+		templateData.GenerateEphemeralResourceDocumentationFile(targetFilePath, object)
+	}
+}
+
 func (t *Terraform) GenerateResourceMetadata(object api.Resource, templateData TemplateData, outputFolder string) {
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_generated_meta.yaml", t.FullResourceName(object)))
@@ -191,10 +283,10 @@ func (t *Terraform) GenerateResourceMetadata(object api.Resource, templateData T
 // resource's `generated_meta.yaml` file.
 func (t *Terraform) GenerateResourceMetadataFile(object api.Resource, targetFilePath string) {
 	targetFolder := path.Dir(targetFilePath)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
-	templateData := NewTemplateData("", t.TargetVersionName, t.templateFS)
+	templateData := t.newTemplateData("", t.templateFS)
 	templateData.GenerateMetadataFile(targetFilePath, object)
 }
 
@@ -214,7 +306,7 @@ func (t *Terraform) GenerateResourceTestsLegacy(object api.Resource, templateDat
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_generated_test.go", t.ResourceGoFilename(object)))
@@ -245,7 +337,7 @@ func (t *Terraform) GenerateResourceTests(object api.Resource, templateData Temp
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_generated_test.go", t.ResourceGoFilename(object)))
@@ -259,7 +351,7 @@ func (t *Terraform) GenerateResourceSweeper(object api.Resource, templateData Te
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_sweeper.go", t.ResourceGoFilename(object)))
@@ -273,10 +365,10 @@ func (t *Terraform) GenerateResourceSweeperFile(object api.Resource, targetFileP
 		log.Fatalf("attempting to generate a sweeper for unswept resource %q", object.Name)
 	}
 	targetFolder := path.Dir(targetFilePath)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
-	templateData := NewTemplateData("", t.TargetVersionName, t.templateFS)
+	templateData := t.newTemplateData("", t.templateFS)
 	templateData.GenerateSweeperFile(targetFilePath, object)
 }
 
@@ -287,7 +379,7 @@ func (t *Terraform) GenerateSingularDataSource(object api.Resource, templateData
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s.go", t.ResourceGoFilename(object)))
@@ -295,13 +387,13 @@ func (t *Terraform) GenerateSingularDataSource(object api.Resource, templateData
 }
 
 func (t *Terraform) GenerateSingularDataSourceTestsLegacy(object api.Resource, templateData TemplateData, outputFolder string) {
-	if !object.ShouldGenerateSingularDataSourceTests() {
+	if !object.ShouldGenerateSingularDataSource() || !object.ShouldGenerateSingularDataSourceTests() {
 		return
 	}
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s_test.go", t.ResourceGoFilename(object)))
@@ -318,13 +410,13 @@ func (t *Terraform) GenerateSingularDataSourceTests(object api.Resource, templat
 		return
 	}
 
-	if !object.ShouldGenerateSingularDataSourceTests() {
+	if !object.ShouldGenerateSingularDataSource() || !object.ShouldGenerateSingularDataSourceTests() {
 		return
 	}
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s_test.go", t.ResourceGoFilename(object)))
@@ -332,28 +424,76 @@ func (t *Terraform) GenerateSingularDataSourceTests(object api.Resource, templat
 
 }
 
+// GeneratePluralDataSource generates the plural data source that lists the
+// resource's collection, along with its documentation.
+func (t *Terraform) GeneratePluralDataSource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if !object.ShouldGeneratePluralDataSource() {
+		return
+	}
+
+	if generateCode {
+		productName := t.Product.ApiName
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+		if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s.go", google.Plural(t.ResourceGoFilename(object))))
+		templateData.GeneratePluralDataSourceFile(targetFilePath, object)
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "d")
+		if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", google.Plural(t.FullResourceName(object))))
+		templateData.GeneratePluralDataSourceDocumentationFile(targetFilePath, object)
+	}
+}
+
+func (t *Terraform) GeneratePluralDataSourceTests(object api.Resource, templateData TemplateData, outputFolder string) {
+	if !object.ShouldGeneratePluralDataSource() || !object.ShouldGenerateSingularDataSourceTests() {
+		return
+	}
+	if object.Samples != nil && object.Examples != nil {
+		log.Fatalf("Both Samples and Examples block exist in %v", object.Name)
+	}
+
+	productName := t.Product.ApiName
+	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
+		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+	}
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s_test.go", google.Plural(t.ResourceGoFilename(object))))
+	if object.Examples != nil {
+		templateData.GeneratePluralDataSourceTestFileLegacy(targetFilePath, object)
+		return
+	}
+	templateData.GeneratePluralDataSourceTestFile(targetFilePath, object)
+}
+
 // GenerateProduct creates the product.go file for a given service directory.
 // This will be used to seed the directory and add a package-level comment
 // specific to the product.
 func (t *Terraform) GenerateProduct(outputFolder string) {
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 
 	targetFilePath := path.Join(targetFolder, "product.go")
-	templateData := NewTemplateData(outputFolder, t.TargetVersionName, t.templateFS)
+	templateData := t.newTemplateData(outputFolder, t.templateFS)
 	templateData.GenerateProductFile(targetFilePath, *t.Product)
 }
 
 // GenerateProduct creates the product.go file for the bazel version of the MM compiler.
 func (t *Terraform) GenerateProductFile(targetFilePath string) {
 	targetFolder := path.Dir(targetFilePath)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 
-	templateData := NewTemplateData("", t.TargetVersionName, t.templateFS)
+	templateData := t.newTemplateData("", t.templateFS)
 	templateData.GenerateProductFile(targetFilePath, *t.Product)
 }
 
@@ -367,21 +507,21 @@ func (t *Terraform) GenerateOperation(outputFolder string) {
 	}
 
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_operation.go", google.Underscore(t.Product.Name)))
-	templateData := NewTemplateData(outputFolder, t.TargetVersionName, t.templateFS)
+	templateData := t.newTemplateData(outputFolder, t.templateFS)
 	templateData.GenerateOperationFile(targetFilePath, *asyncObjects[0])
 }
 
 // GenerateProduct creates the operation.go file for the bazel version of the MM compiler.
 func (t *Terraform) GenerateOperationFile(object api.Resource, targetFilePath string) {
 	targetFolder := path.Dir(targetFilePath)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
-	templateData := NewTemplateData("", t.TargetVersionName, t.templateFS)
+	templateData := t.newTemplateData("", t.templateFS)
 	templateData.GenerateOperationFile(targetFilePath, object)
 }
 
@@ -394,7 +534,7 @@ func (t *Terraform) GenerateIamPolicyLegacy(object api.Resource, templateData Te
 	if generateCode && object.IamPolicy != nil && (object.IamPolicy.MinVersion == "" || slices.Index(product.ORDER, object.IamPolicy.MinVersion) <= slices.Index(product.ORDER, t.TargetVersionName)) {
 		productName := t.Product.ApiName
 		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("iam_%s.go", t.ResourceGoFilename(object)))
@@ -430,7 +570,7 @@ func (t *Terraform) GenerateIamPolicy(object api.Resource, templateData Template
 	if generateCode && object.IamPolicy != nil && (object.IamPolicy.MinVersion == "" || slices.Index(product.ORDER, object.IamPolicy.MinVersion) <= slices.Index(product.ORDER, t.TargetVersionName)) {
 		productName := t.Product.ApiName
 		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		if err := t.output().MkdirAll(targetFolder, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("iam_%s.go", t.ResourceGoFilename(object)))
@@ -452,14 +592,14 @@ func (t *Terraform) GenerateIamPolicy(object api.Resource, templateData Template
 
 func (t *Terraform) GenerateIamDocumentation(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
 	resourceDocFolder := path.Join(outputFolder, "website", "docs", "r")
-	if err := os.MkdirAll(resourceDocFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(resourceDocFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", resourceDocFolder, err))
 	}
 	targetFilePath := path.Join(resourceDocFolder, fmt.Sprintf("%s_iam.html.markdown", t.FullResourceName(object)))
 	templateData.GenerateIamResourceDocumentationFile(targetFilePath, object)
 
 	datasourceDocFolder := path.Join(outputFolder, "website", "docs", "d")
-	if err := os.MkdirAll(datasourceDocFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(datasourceDocFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", datasourceDocFolder, err))
 	}
 	targetFilePath = path.Join(datasourceDocFolder, fmt.Sprintf("%s_iam_policy.html.markdown", t.FullResourceName(object)))
@@ -514,6 +654,11 @@ func (t Terraform) CopyCommonFiles(outputFolder string, generateCode, generateDo
 	log.Printf("Copying common files for %s", ProviderName(t))
 
 	files := t.getCommonCopyFiles(t.TargetVersionName, generateCode, generateDocs)
+	if t.Report != nil {
+		output := newRecordingOutputFS(t.output())
+		t.Output = output
+		defer func() { t.Report.addCommonFiles(output.Files()) }()
+	}
 	t.CopyFileList(outputFolder, files, generateCode)
 }
 
@@ -598,12 +743,12 @@ func (t Terraform) CopyFileList(outputFolder string, files map[string]string, ge
 		targetFile := filepath.Join(outputFolder, target)
 		targetDir := filepath.Dir(targetFile)
 
-		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		if err := t.output().MkdirAll(targetDir, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating output directory %v: %v", targetDir, err))
 		}
 		// If we've modified a file since starting an MM run, it's a reasonable
 		// assumption that it was this run that modified it.
-		if info, err := os.Stat(targetFile); !errors.Is(err, os.ErrNotExist) && t.StartTime.Before(info.ModTime()) {
+		if info, err := t.output().Stat(targetFile); !errors.Is(err, os.ErrNotExist) && t.StartTime.Before(info.ModTime()) {
 			log.Fatalf("%s was already modified during this run at %s", targetFile, info.ModTime().String())
 		}
 
@@ -619,7 +764,7 @@ func (t Terraform) CopyFileList(outputFolder string, files map[string]string, ge
 			permission = 0644
 		}
 
-		err = os.WriteFile(targetFile, sourceByte, permission)
+		err = t.output().WriteFile(targetFile, sourceByte, permission)
 		if err != nil {
 			log.Fatalf("Cannot write target file %s while copying: %s", target, err)
 		}
@@ -641,7 +786,12 @@ func (t Terraform) CopyFileList(outputFolder string, files map[string]string, ge
 func (t Terraform) CompileCommonFiles(outputFolder string, products []*api.Product, overridePath string) {
 	t.generateResourcesForVersion(products)
 	files := t.getCommonCompileFiles(t.TargetVersionName)
-	templateData := NewTemplateData(outputFolder, t.TargetVersionName, t.templateFS)
+	if t.Report != nil {
+		output := newRecordingOutputFS(t.output())
+		t.Output = output
+		defer func() { t.Report.addCommonFiles(output.Files()) }()
+	}
+	templateData := t.newTemplateData(outputFolder, t.templateFS)
 	t.CompileFileList(outputFolder, files, *templateData, products)
 }
 
@@ -710,14 +860,14 @@ func (t Terraform) CompileFileList(outputFolder string, files map[string]string,
 		Products:  products,
 	}
 
-	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+	if err := t.output().MkdirAll(outputFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
 	}
 
 	for target, source := range files {
 		targetFile := filepath.Join(outputFolder, target)
 		targetDir := filepath.Dir(targetFile)
-		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		if err := t.output().MkdirAll(targetDir, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating output directory %v: %v", targetDir, err))
 		}
 
@@ -729,7 +879,7 @@ func (t Terraform) CompileFileList(outputFolder string, files map[string]string,
 
 		fileTemplate.GenerateFile(targetFile, source, providerWithProducts, formatFile, templates...)
 		// continue to next file if no file was generated
-		if _, err := os.Stat(targetFile); errors.Is(err, os.ErrNotExist) {
+		if _, err := t.output().Stat(targetFile); errors.Is(err, os.ErrNotExist) {
 			continue
 		}
 		t.replaceImportPath(outputFolder, target)
@@ -747,7 +897,7 @@ func (t Terraform) addCopyfileHeader(srcpath, outputFolder, target string) {
 	}
 
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := t.output().ReadFile(targetFile)
 	if err != nil {
 		log.Fatalf("Cannot read file %s to add copy file header: %s", targetFile, err)
 	}
@@ -791,7 +941,7 @@ func (t Terraform) addCopyfileHeader(srcpath, outputFolder, target string) {
 		}
 	}
 
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = t.output().WriteFile(targetFile, sourceByte, 0644)
 	if err != nil {
 		log.Fatalf("Cannot write file %s to add copy file header: %s", target, err)
 	}
@@ -851,13 +1001,13 @@ func (t Terraform) addHashicorpCopyRightHeader(outputFolder, target string) {
 	header := commentBlock(copyrightHeader, lang)
 
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := t.output().ReadFile(targetFile)
 	if err != nil {
 		log.Fatalf("Cannot read file %s to add Hashicorp copy right: %s", targetFile, err)
 	}
 
 	sourceByte = google.Concat([]byte(header), sourceByte)
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = t.output().WriteFile(targetFile, sourceByte, 0644)
 	if err != nil {
 		log.Fatalf("Cannot write file %s to add Hashicorp copy right: %s", target, err)
 	}
@@ -879,7 +1029,7 @@ func expectedOutputFolder(outputFolder string) bool {
 
 func (t Terraform) replaceImportPath(outputFolder, target string) {
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := t.output().ReadFile(targetFile)
 	if err != nil {
 		log.Fatalf("Cannot read file %s to replace import path: %s", targetFile, err)
 	}
@@ -921,7 +1071,7 @@ func (t Terraform) replaceImportPath(outputFolder, target string) {
 		}
 	}
 
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = t.output().WriteFile(targetFile, sourceByte, 0644)
 	if err != nil {
 		log.Fatalf("Cannot write file %s to replace import path: %s", target, err)
 	}
@@ -975,6 +1125,7 @@ func (t Terraform) GetMmv1ServicesInVersion(products []*api.Product) []string {
 // #    terraform_name:
 // #    resource_name:
 // #    iam_class_name:
+// #    plural_data_source_name:
 // # }
 // # The variable resources_for_version is used to generate resources in file
 // # mmv1/third_party/terraform/provider/provider_mmv1_resources.go.erb
@@ -986,11 +1137,21 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 				continue
 			}
 
+			// Ephemeral resources are served by the framework provider through
+			// registry.EphemeralResources
+			if object.Ephemeral != nil {
+				continue
+			}
+
 			var resourceName string
 
 			if !object.IsExcluded() {
 				t.ResourceCount++
-				resourceName = fmt.Sprintf("%s.Resource%s", service, object.ResourceName())
+				// Plugin framework resources are served by the framework provider
+				// through registry.FrameworkResources instead
+				if !object.FrameworkResource {
+					resourceName = fmt.Sprintf("%s.Resource%s", service, object.ResourceName())
+				}
 			}
 
 			var iamClassName string
@@ -1003,10 +1164,16 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 				}
 			}
 
+			var pluralDataSourceName string
+			if !object.IsExcluded() && object.ShouldGeneratePluralDataSource() {
+				pluralDataSourceName = object.PluralDataSourceName()
+			}
+
 			t.ResourcesForVersion = append(t.ResourcesForVersion, map[string]string{
-				"TerraformName": object.TerraformName(),
-				"ResourceName":  resourceName,
-				"IamClassName":  iamClassName,
+				"TerraformName":        object.TerraformName(),
+				"ResourceName":         resourceName,
+				"IamClassName":         iamClassName,
+				"PluralDataSourceName": pluralDataSourceName,
 			})
 		}
 	}
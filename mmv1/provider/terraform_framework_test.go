@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+	"github.com/google/go-cmp/cmp"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+const (
+	frameworkTestData    = "testdata/framework"
+	ephemeralTestData    = "testdata/ephemeral"
+	pluralTestData       = "testdata/plural"
+	testOutput           = "terraform-provider-google"
+	frameworkResource    = "google/services/widgets/resource_fw_widgets_widget.go"
+	sdkResource          = "google/services/widgets/resource_widgets_widget.go"
+	ephemeralResource    = "google/services/widgets/ephemeral_widgets_widget_token.go"
+	ephemeralTest        = "google/services/widgets/ephemeral_widgets_widget_token_generated_test.go"
+	pluralDataSource     = "google/services/widgets/data_source_widgets_widgets.go"
+	pluralDataSourceTest = "google/services/widgets/data_source_widgets_widgets_test.go"
+)
+
+// generateWidgets generates the Widgets test product in testData after
+// applying configure, if set, to its resources.
+func generateWidgets(t *testing.T, testData string, configure func(r *api.Resource)) *google.MemoryOutputFS {
+	t.Helper()
+	sysfs, err := google.NewOverlayFS("", testData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := loader.NewLoader(loader.Config{Version: "ga", BaseDirectory: testData, Sysfs: sysfs})
+	if err := l.LoadProducts(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.AddExtraFields(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	output := google.NewMemoryOutputFS()
+	for _, p := range l.Products {
+		if configure != nil {
+			for _, r := range p.Objects {
+				configure(r)
+			}
+		}
+		tf := NewTerraform(p, "ga", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), os.DirFS(".."))
+		tf.Output = output
+		tf.Generate(testOutput, nil, true, true)
+	}
+	return output
+}
+
+func readGenerated(t *testing.T, output *google.MemoryOutputFS, name string) []byte {
+	t.Helper()
+	b, err := output.ReadFile(filepath.Join(testOutput, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// checkGolden compares a generated file to its golden file in
+// testData/golden, or updates the golden file when run with -update.
+func checkGolden(t *testing.T, testData string, output *google.MemoryOutputFS, name string) {
+	t.Helper()
+	got := readGenerated(t, output, name)
+	golden := filepath.Join(testData, "golden", filepath.Base(name)+".golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("%s mismatch, run with -update to update it (-want +got):\n%s", golden, diff)
+	}
+}
+
+func TestFrameworkResourceGolden(t *testing.T) {
+	for _, tc := range []struct {
+		framework bool
+		file      string
+	}{
+		{framework: true, file: frameworkResource},
+		{framework: false, file: sdkResource},
+	} {
+		t.Run(filepath.Base(tc.file), func(t *testing.T) {
+			output := generateWidgets(t, frameworkTestData, func(r *api.Resource) { r.FrameworkResource = tc.framework })
+			checkGolden(t, frameworkTestData, output, tc.file)
+		})
+	}
+}
+
+func TestEphemeralResourceGolden(t *testing.T) {
+	output := generateWidgets(t, ephemeralTestData, nil)
+	for _, file := range []string{ephemeralResource, ephemeralTest} {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			checkGolden(t, ephemeralTestData, output, file)
+		})
+	}
+}
+
+func TestPluralDataSourceGolden(t *testing.T) {
+	output := generateWidgets(t, pluralTestData, nil)
+	for _, file := range []string{pluralDataSource, pluralDataSourceTest} {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			checkGolden(t, pluralTestData, output, file)
+		})
+	}
+}
+
+// TestFrameworkResourceParity checks that a plugin framework resource has the
+// same schema and CRUD functions as the SDK resource generated from the same
+// YAML, and that the other generated files don't depend on the template.
+// Plugin framework resources don't have a resource identity.
+func TestFrameworkResourceParity(t *testing.T) {
+	fwOutput := generateWidgets(t, frameworkTestData, func(r *api.Resource) { r.FrameworkResource = true })
+	sdkOutput := generateWidgets(t, frameworkTestData, func(r *api.Resource) {
+		r.FrameworkResource = false
+		r.ExcludeIdentity = true
+	})
+
+	fwFiles, sdkFiles := fwOutput.Files(), sdkOutput.Files()
+	fwFiles = slices.DeleteFunc(fwFiles, func(f string) bool { return strings.HasSuffix(f, frameworkResource) })
+	sdkFiles = slices.DeleteFunc(sdkFiles, func(f string) bool { return strings.HasSuffix(f, sdkResource) })
+	if diff := cmp.Diff(sdkFiles, fwFiles); diff != "" {
+		t.Fatalf("generated files mismatch (-sdk +framework):\n%s", diff)
+	}
+	for _, f := range fwFiles {
+		fw, _ := fwOutput.ReadFile(f)
+		sdk, _ := sdkOutput.ReadFile(f)
+		if !bytes.Equal(fw, sdk) {
+			t.Errorf("%s differs between framework and SDK generation:\n%s", f, cmp.Diff(string(sdk), string(fw)))
+		}
+	}
+
+	fw := parseGenerated(t, readGenerated(t, fwOutput, frameworkResource))
+	sdk := parseGenerated(t, readGenerated(t, sdkOutput, sdkResource))
+
+	fwSchema := schemaPaths(findFunc(t, fw, "Schema"), "", "Attributes", "Blocks")
+	fwSchema = slices.DeleteFunc(fwSchema, func(p string) bool { return p == "id" || p == "timeouts" })
+	sdkSchema := schemaPaths(findFunc(t, sdk, "ResourceWidgetsWidget"), "", "Schema")
+	if diff := cmp.Diff(sdkSchema, fwSchema); diff != "" {
+		t.Errorf("schema fields mismatch (-sdk +framework):\n%s", diff)
+	}
+
+	fwFuncs, sdkFuncs := funcSources(t, fw), funcSources(t, sdk)
+	for name, src := range sdkFuncs {
+		switch name {
+		case "init", "ResourceWidgetsWidget", "resourceWidgetsWidgetImport":
+			continue
+		}
+		want := strings.ReplaceAll(src, "*schema.ResourceData", "tpgresource.TerraformResourceData")
+		if diff := cmp.Diff(want, fwFuncs[name]); diff != "" {
+			t.Errorf("%s mismatch (-sdk +framework):\n%s", name, diff)
+		}
+	}
+}
+
+func parseGenerated(t *testing.T, src []byte) *ast.File {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+	if err != nil {
+		t.Fatalf("generated code doesn't parse: %v", err)
+	}
+	return f
+}
+
+func findFunc(t *testing.T, f *ast.File, name string) *ast.FuncDecl {
+	t.Helper()
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == name {
+			return fn
+		}
+	}
+	t.Fatalf("function %s not found", name)
+	return nil
+}
+
+// funcSources returns the source of the top-level functions without a
+// receiver, by name.
+func funcSources(t *testing.T, f *ast.File) map[string]string {
+	t.Helper()
+	funcs := make(map[string]string)
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil {
+			continue
+		}
+		var b bytes.Buffer
+		if err := printer.Fprint(&b, token.NewFileSet(), fn); err != nil {
+			t.Fatal(err)
+		}
+		funcs[fn.Name.Name] = b.String()
+	}
+	return funcs
+}
+
+// schemaPaths returns the sorted paths, such as "config.color", of the fields
+// in the maps of schema fields under node. keys are the names of the struct
+// fields holding those maps.
+func schemaPaths(node ast.Node, prefix string, keys ...string) []string {
+	var paths []string
+	ast.Inspect(node, func(n ast.Node) bool {
+		kv, ok := n.(*ast.KeyValueExpr)
+		if !ok {
+			return true
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok || !slices.Contains(keys, key.Name) {
+			return true
+		}
+		m, ok := kv.Value.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		if _, ok := m.Type.(*ast.MapType); !ok {
+			return true
+		}
+		for _, elt := range m.Elts {
+			field, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			lit, ok := field.Key.(*ast.BasicLit)
+			if !ok {
+				continue
+			}
+			name, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				continue
+			}
+			paths = append(paths, prefix+name)
+			paths = append(paths, schemaPaths(field.Value, prefix+name+".", keys...)...)
+		}
+		return false
+	})
+	sort.Strings(paths)
+	return paths
+}
@@ -47,15 +47,15 @@ func NewTerraformOiCS(product *api.Product, versionName string, startTime time.T
 	return toics
 }
 
-func (toics TerraformOiCS) Generate(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
-	toics.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
+func (toics TerraformOiCS) Generate(outputFolder string, filter *ResourceFilter, generateCode, generateDocs bool) {
+	toics.GenerateObjects(outputFolder, filter, generateCode, generateDocs)
 }
 
-func (toics TerraformOiCS) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (toics TerraformOiCS) GenerateObjects(outputFolder string, filter *ResourceFilter, generateCode, generateDocs bool) {
 	for _, object := range toics.Product.Objects {
 		object.ExcludeIfNotInVersion(toics.Product.Version)
 
-		if resourceToGenerate != "" && object.Name != resourceToGenerate {
+		if !filter.Matches(toics.Product, object) {
 			log.Printf("Excluding %s per user request", object.Name)
 			continue
 		}
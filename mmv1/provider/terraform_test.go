@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+func TestCopyFileListToMemory(t *testing.T) {
+	output := google.NewMemoryOutputFS()
+	tf := Terraform{
+		TargetVersionName: "beta",
+		StartTime:         time.Now(),
+		Output:            output,
+		templateFS: fstest.MapFS{
+			"third_party/terraform/tpgresource/utils.go": {Data: []byte(
+				"package tpgresource\n\nimport _ \"github.com/hashicorp/terraform-provider-google/google/transport\"\n",
+			)},
+			"third_party/terraform/scripts/run.sh": {Data: []byte("#!/bin/sh\n")},
+		},
+	}
+
+	outputFolder := "terraform-provider-google-beta"
+	tf.CopyFileList(outputFolder, map[string]string{
+		"google-beta/tpgresource/utils.go": "third_party/terraform/tpgresource/utils.go",
+		"scripts/run.sh":                   "third_party/terraform/scripts/run.sh",
+	}, true)
+
+	b, err := output.ReadFile(filepath.Join(outputFolder, "google-beta/tpgresource/utils.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(b)
+	for _, want := range []string{
+		"// Copyright (c) HashiCorp, Inc.",
+		"Source file: https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/third_party/terraform/tpgresource/utils.go",
+		`import _ "github.com/hashicorp/terraform-provider-google-beta/google-beta/transport"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("utils.go does not contain %q:\n%s", want, got)
+		}
+	}
+
+	info, err := output.Stat(filepath.Join(outputFolder, "scripts/run.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode() != 0755 {
+		t.Errorf("run.sh mode = %s, want -rwxr-xr-x", info.Mode())
+	}
+}
@@ -65,21 +65,21 @@ func NewTerraformGoogleConversion(product *api.Product, versionName string, star
 	return t
 }
 
-func (tgc TerraformGoogleConversion) Generate(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversion) Generate(outputFolder string, filter *ResourceFilter, generateCode, generateDocs bool) {
 	// Temporary shim to generate the missing resources directory. Can be removed
 	// once the folder exists downstream.
 	resourcesFolder := path.Join(outputFolder, "converters/google/resources")
 	if err := os.MkdirAll(resourcesFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", resourcesFolder, err))
 	}
-	tgc.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
+	tgc.GenerateObjects(outputFolder, filter, generateCode, generateDocs)
 }
 
-func (tgc TerraformGoogleConversion) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversion) GenerateObjects(outputFolder string, filter *ResourceFilter, generateCode, generateDocs bool) {
 	for _, object := range tgc.Product.Objects {
 		object.ExcludeIfNotInVersion(tgc.Product.Version)
 
-		if resourceToGenerate != "" && object.Name != resourceToGenerate {
+		if !filter.Matches(tgc.Product, object) {
 			log.Printf("Excluding %s per user request", object.Name)
 			continue
 		}
@@ -93,6 +93,10 @@ func (tgc TerraformGoogleConversion) GenerateObject(object api.Resource, outputF
 		log.Printf("Skipping fine-grained resource %s", object.Name)
 		return
 	}
+	if object.Ephemeral != nil {
+		log.Printf("Skipping ephemeral resource %s", object.Name)
+		return
+	}
 
 	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName, tgc.templateFS)
 
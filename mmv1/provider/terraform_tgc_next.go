@@ -82,11 +82,11 @@ func NewTerraformGoogleConversionNext(product *api.Product, versionName string,
 	return t
 }
 
-func (tgc TerraformGoogleConversionNext) Generate(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversionNext) Generate(outputFolder string, filter *ResourceFilter, generateCode, generateDocs bool) {
 	for _, object := range tgc.Product.Objects {
 		object.ExcludeIfNotInVersion(tgc.Product.Version)
 
-		if resourceToGenerate != "" && object.Name != resourceToGenerate {
+		if !filter.Matches(tgc.Product, object) {
 			log.Printf("Excluding %s per user request", object.Name)
 			continue
 		}
@@ -131,7 +131,7 @@ func (tgc TerraformGoogleConversionNext) GenerateResource(object api.Resource, t
 	templateData.GenerateTGCResourceFile(templatePath, targetFilePath, object)
 }
 
-func (tgc TerraformGoogleConversionNext) GenerateCaiToHclObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversionNext) GenerateCaiToHclObjects(outputFolder string, filter *ResourceFilter, generateCode, generateDocs bool) {
 }
 
 func (tgc *TerraformGoogleConversionNext) GenerateResourceTests(object api.Resource, templateData TemplateData, outputFolder string) {
@@ -0,0 +1,52 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/schema"
+)
+
+func TestProductsMatchSchema(t *testing.T) {
+	_, testFilePath, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("Failed to get current test file path")
+	}
+	mmv1Dir := filepath.Dir(filepath.Dir(testFilePath))
+
+	g := schema.NewGenerator(mmv1Dir)
+	productSchema, err := g.ProductSchema()
+	if err != nil {
+		t.Fatalf("Error building product schema: %v", err)
+	}
+	resourceSchema, err := g.ResourceSchema()
+	if err != nil {
+		t.Fatalf("Error building resource schema: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(mmv1Dir, "products", "*", "*.yaml"))
+	if err != nil {
+		t.Fatalf("Error listing products: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("No product YAML files found")
+	}
+
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Error reading %s: %v", path, err)
+		}
+		s := resourceSchema
+		if filepath.Base(path) == "product.yaml" {
+			s = productSchema
+		}
+		relPath, _ := filepath.Rel(mmv1Dir, path)
+		if es := s.ValidateYAML(content, relPath); len(es) > 0 {
+			t.Error(errors.Join(es...))
+		}
+	}
+}
@@ -47,6 +47,14 @@ type MetadataField struct {
 	Field        string `yaml:"field"`
 	ProviderOnly bool   `yaml:"provider_only"`
 	Json         bool   `yaml:"json"`
+	Type         string `yaml:"type"`
+	Required     bool   `yaml:"required"`
+	Output       bool   `yaml:"output"`
+	Immutable    bool   `yaml:"immutable"`
+	Sensitive    bool   `yaml:"sensitive"`
+	WriteOnly    bool   `yaml:"write_only"`
+	MinVersion   string `yaml:"min_version"`
+	Deprecated   bool   `yaml:"deprecated"`
 }
 
 type MetadataCache struct {
@@ -5,7 +5,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
 )
 
 func DefaultProjectModify(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, providerConfigProject string) {
@@ -82,3 +86,159 @@ func DefaultZoneModify(ctx context.Context, req resource.ModifyPlanRequest, resp
 	}
 	return
 }
+
+// SetLabelsModify sets terraform_labels and effective_labels in the plan, from
+// the labels field and the provider's default labels. It is the plugin framework
+// equivalent of tpgresource.SetLabelsDiff.
+func SetLabelsModify(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, config *transport_tpg.Config, skipAttribution bool) {
+	var labels types.Map
+	diags := req.Plan.GetAttribute(ctx, path.Root("labels"), &labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// If "labels" field is computed, set "terraform_labels" and "effective_labels" to computed.
+	if labels.IsUnknown() {
+		resp.Plan.SetAttribute(ctx, path.Root("terraform_labels"), types.MapUnknown(types.StringType))
+		resp.Plan.SetAttribute(ctx, path.Root("effective_labels"), types.MapUnknown(types.StringType))
+		return
+	}
+
+	oldTerraformLabels := make(map[string]string)
+	effectiveLabels := make(map[string]string)
+	if !req.State.Raw.IsNull() {
+		var oldLabels, oldEffectiveLabels types.Map
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("terraform_labels"), &oldLabels)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("effective_labels"), &oldEffectiveLabels)...)
+		resp.Diagnostics.Append(oldLabels.ElementsAs(ctx, &oldTerraformLabels, false)...)
+		resp.Diagnostics.Append(oldEffectiveLabels.ElementsAs(ctx, &effectiveLabels, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Merge provider default labels with the user defined labels in the resource to get terraform managed labels
+	terraformLabels := make(map[string]string)
+	for k, v := range config.DefaultLabels {
+		terraformLabels[k] = v
+	}
+
+	// Append optional label indicating the resource was provisioned using Terraform
+	if !skipAttribution && config.AddTerraformAttributionLabel {
+		_, hasExistingLabel := effectiveLabels[transport_tpg.AttributionKey]
+		if hasExistingLabel ||
+			config.TerraformAttributionLabelAdditionStrategy == transport_tpg.ProactiveAttributionStrategy ||
+			(config.TerraformAttributionLabelAdditionStrategy == transport_tpg.CreateOnlyAttributionStrategy && req.State.Raw.IsNull()) {
+			terraformLabels[transport_tpg.AttributionKey] = transport_tpg.AttributionValue
+		}
+	}
+
+	userLabels := make(map[string]string)
+	resp.Diagnostics.Append(labels.ElementsAs(ctx, &userLabels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for k, v := range userLabels {
+		terraformLabels[k] = v
+	}
+
+	for k, v := range terraformLabels {
+		effectiveLabels[k] = v
+	}
+	for k := range oldTerraformLabels {
+		if _, ok := terraformLabels[k]; !ok {
+			delete(effectiveLabels, k)
+		}
+	}
+
+	terraformLabelsValue, diags := types.MapValueFrom(ctx, types.StringType, terraformLabels)
+	resp.Diagnostics.Append(diags...)
+	effectiveLabelsValue, diags := types.MapValueFrom(ctx, types.StringType, effectiveLabels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Plan.SetAttribute(ctx, path.Root("terraform_labels"), terraformLabelsValue)
+	resp.Plan.SetAttribute(ctx, path.Root("effective_labels"), effectiveLabelsValue)
+}
+
+// SetAnnotationsModify sets effective_annotations in the plan from the
+// annotations field. It is the plugin framework equivalent of
+// tpgresource.SetAnnotationsDiff.
+func SetAnnotationsModify(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var annotations types.Map
+	diags := req.Plan.GetAttribute(ctx, path.Root("annotations"), &annotations)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// If "annotations" field is computed, set "effective_annotations" to computed.
+	if annotations.IsUnknown() {
+		resp.Plan.SetAttribute(ctx, path.Root("effective_annotations"), types.MapUnknown(types.StringType))
+		return
+	}
+
+	oldAnnotations := make(map[string]string)
+	effectiveAnnotations := make(map[string]string)
+	if !req.State.Raw.IsNull() {
+		var oldAnnotationsValue, oldEffectiveAnnotations types.Map
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("annotations"), &oldAnnotationsValue)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("effective_annotations"), &oldEffectiveAnnotations)...)
+		resp.Diagnostics.Append(oldAnnotationsValue.ElementsAs(ctx, &oldAnnotations, false)...)
+		resp.Diagnostics.Append(oldEffectiveAnnotations.ElementsAs(ctx, &effectiveAnnotations, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	newAnnotations := make(map[string]string)
+	resp.Diagnostics.Append(annotations.ElementsAs(ctx, &newAnnotations, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for k, v := range newAnnotations {
+		effectiveAnnotations[k] = v
+	}
+	for k := range oldAnnotations {
+		if _, ok := newAnnotations[k]; !ok {
+			delete(effectiveAnnotations, k)
+		}
+	}
+
+	effectiveAnnotationsValue, diags := types.MapValueFrom(ctx, types.StringType, effectiveAnnotations)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Plan.SetAttribute(ctx, path.Root("effective_annotations"), effectiveAnnotationsValue)
+}
+
+// DiffSuppress returns a plan modifier that keeps the prior value of a string
+// attribute when f reports that the configured value is equivalent to it, as a
+// DiffSuppressFunc does for SDK resources. f is called without resource data.
+func DiffSuppress(f schema.SchemaDiffSuppressFunc) planmodifier.String {
+	return diffSuppressModifier{f: f}
+}
+
+type diffSuppressModifier struct {
+	f schema.SchemaDiffSuppressFunc
+}
+
+func (m diffSuppressModifier) Description(_ context.Context) string {
+	return "Keeps the prior value when the configured value is equivalent to it."
+}
+
+func (m diffSuppressModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m diffSuppressModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+	if m.f(req.Path.String(), req.StateValue.ValueString(), req.PlanValue.ValueString(), nil) {
+		resp.PlanValue = req.StateValue
+	}
+}
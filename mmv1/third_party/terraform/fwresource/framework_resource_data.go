@@ -0,0 +1,535 @@
+package fwresource
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-google/google/tpgresource"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+var _ tpgresource.TerraformResourceData = &ResourceData{}
+
+// ResourceData adapts the plan or state of a plugin framework resource to
+// tpgresource.TerraformResourceData, so that generated framework resources can
+// share expanders, flatteners and custom code with SDK resources.
+//
+// Values are read and written in the shapes used by terraform-plugin-sdk:
+// nested objects are lists with a single element, sets are *schema.Set and
+// unset values are read as the zero value of their type.
+type ResourceData struct {
+	typ      tftypes.Object
+	planned  map[string]tftypes.Value
+	values   map[string]interface{}
+	exists   map[string]bool
+	prior    map[string]interface{}
+	timeouts map[string]time.Duration
+	meta     transport_tpg.ProviderMeta
+}
+
+// NewResourceData reads the attributes of raw, a plan or state. prior is the
+// prior state used to answer HasChange, and may be the zero value when there
+// is no prior state.
+func NewResourceData(raw, prior tftypes.Value) (*ResourceData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	typ, ok := raw.Type().(tftypes.Object)
+	if !ok {
+		diags.AddError("Error reading resource data", fmt.Sprintf("expected an object, found %v", raw.Type()))
+		return nil, diags
+	}
+
+	d := &ResourceData{
+		typ:      typ,
+		planned:  make(map[string]tftypes.Value),
+		values:   make(map[string]interface{}),
+		exists:   make(map[string]bool),
+		prior:    make(map[string]interface{}),
+		timeouts: make(map[string]time.Duration),
+	}
+	if raw.IsKnown() && !raw.IsNull() {
+		if err := raw.As(&d.planned); err != nil {
+			diags.AddError("Error reading resource data", err.Error())
+			return nil, diags
+		}
+	}
+	for name, attrType := range typ.AttributeTypes {
+		v, ok := d.planned[name]
+		if !ok {
+			d.values[name] = zeroValue(attrType)
+			continue
+		}
+		value, err := sdkValue(v)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Error reading %s", name), err.Error())
+			continue
+		}
+		d.values[name] = value
+		d.exists[name] = v.IsKnown() && !v.IsNull()
+	}
+
+	if prior.Type() != nil && prior.IsKnown() && !prior.IsNull() {
+		var priorValues map[string]tftypes.Value
+		if err := prior.As(&priorValues); err != nil {
+			diags.AddError("Error reading prior resource data", err.Error())
+			return nil, diags
+		}
+		for name, v := range priorValues {
+			value, err := sdkValue(v)
+			if err != nil {
+				diags.AddError(fmt.Sprintf("Error reading prior %s", name), err.Error())
+				continue
+			}
+			d.prior[name] = value
+		}
+	}
+	return d, diags
+}
+
+// Get returns the value at key, such as "config.0.enabled", or the zero value
+// of the attribute if it isn't set.
+func (d *ResourceData) Get(key string) interface{} {
+	v, _ := lookup(d.values, key)
+	return v
+}
+
+// GetOk returns the value at key and whether it is set to a non-zero value.
+func (d *ResourceData) GetOk(key string) (interface{}, bool) {
+	v, ok := lookup(d.values, key)
+	return v, ok && d.exists[rootKey(key)] && !isZero(v)
+}
+
+// GetOkExists returns the value at key and whether it is set, including to
+// the zero value of its type.
+func (d *ResourceData) GetOkExists(key string) (interface{}, bool) {
+	v, ok := lookup(d.values, key)
+	if !ok || !d.exists[rootKey(key)] {
+		return v, false
+	}
+	return v, key == rootKey(key) || !isZero(v)
+}
+
+// HasChange reports whether the value at key differs from the prior state.
+func (d *ResourceData) HasChange(key string) bool {
+	v, _ := lookup(d.values, key)
+	o, _ := lookup(d.prior, key)
+	return !reflect.DeepEqual(normalize(v), normalize(o))
+}
+
+// Set sets a top level attribute, in the shape returned by flatteners.
+func (d *ResourceData) Set(key string, value interface{}) error {
+	if _, ok := d.typ.AttributeTypes[key]; !ok {
+		return fmt.Errorf("Invalid address to set: %q", key)
+	}
+	d.values[key] = value
+	d.exists[key] = value != nil
+	return nil
+}
+
+func (d *ResourceData) Id() string {
+	id, _ := d.values["id"].(string)
+	return id
+}
+
+func (d *ResourceData) SetId(id string) {
+	d.values["id"] = id
+	d.exists["id"] = id != ""
+}
+
+// GetProviderMeta reads the provider_meta set by SetProviderMeta into dst,
+// which must be a *transport_tpg.ProviderMeta.
+func (d *ResourceData) GetProviderMeta(dst interface{}) error {
+	m, ok := dst.(*transport_tpg.ProviderMeta)
+	if !ok {
+		return fmt.Errorf("unsupported provider_meta type %T", dst)
+	}
+	*m = d.meta
+	return nil
+}
+
+// SetProviderMeta sets the provider_meta read from the request.
+func (d *ResourceData) SetProviderMeta(meta transport_tpg.ProviderMeta) {
+	d.meta = meta
+}
+
+// Timeout returns the timeout set for key by SetTimeout.
+func (d *ResourceData) Timeout(key string) time.Duration {
+	return d.timeouts[key]
+}
+
+// SetTimeout sets the timeout for key, such as schema.TimeoutCreate, read
+// from the resource's timeouts block.
+func (d *ResourceData) SetTimeout(key string, timeout time.Duration) {
+	d.timeouts[key] = timeout
+}
+
+// State returns the resource data as a value of the resource's type, after
+// a read. Zero values of attributes that were null in the prior state are
+// kept null, as the SDK does, so that unset optional fields don't produce a
+// diff.
+func (d *ResourceData) State() (tftypes.Value, diag.Diagnostics) {
+	return d.state(false)
+}
+
+// AppliedState returns the resource data as a value of the resource's type,
+// after a create or update. Values that were known in the plan are kept, as
+// Terraform requires applied values to match them, and only unknown values are
+// read from the resource data.
+func (d *ResourceData) AppliedState() (tftypes.Value, diag.Diagnostics) {
+	return d.state(true)
+}
+
+func (d *ResourceData) state(applied bool) (tftypes.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	attrs := make(map[string]tftypes.Value, len(d.typ.AttributeTypes))
+	for name, attrType := range d.typ.AttributeTypes {
+		v, err := tfValue(attrType, d.values[name], d.planned[name], applied)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Error setting %s", name), err.Error())
+			continue
+		}
+		attrs[name] = v
+	}
+	if diags.HasError() {
+		return tftypes.Value{}, diags
+	}
+	return tftypes.NewValue(d.typ, attrs), diags
+}
+
+// HashValue is a schema.SchemaSetFunc for values of any type, used for sets
+// that have no hash function of their own.
+func HashValue(v interface{}) int {
+	return schema.HashString(fmt.Sprintf("%v", normalize(v)))
+}
+
+func rootKey(key string) string {
+	root, _, _ := strings.Cut(key, ".")
+	return root
+}
+
+// lookup walks a key such as "config.0.enabled" through SDK shaped values.
+func lookup(values map[string]interface{}, key string) (interface{}, bool) {
+	parts := strings.Split(key, ".")
+	v, ok := values[parts[0]]
+	if !ok {
+		return nil, false
+	}
+	for _, part := range parts[1:] {
+		if set, ok := v.(*schema.Set); ok {
+			v = set.List()
+		}
+		switch t := v.(type) {
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, false
+			}
+			v = t[i]
+		case map[string]interface{}:
+			if v, ok = t[part]; !ok {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func isZero(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	if set, ok := v.(*schema.Set); ok {
+		return set.Len() == 0
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+// normalize replaces sets with lists sorted by their elements, so that values
+// can be compared and hashed.
+func normalize(v interface{}) interface{} {
+	switch t := v.(type) {
+	case *schema.Set:
+		l := make([]interface{}, 0, t.Len())
+		for _, e := range t.List() {
+			l = append(l, normalize(e))
+		}
+		sort.Slice(l, func(i, j int) bool {
+			return fmt.Sprintf("%v", l[i]) < fmt.Sprintf("%v", l[j])
+		})
+		return l
+	case []interface{}:
+		l := make([]interface{}, 0, len(t))
+		for _, e := range t {
+			l = append(l, normalize(e))
+		}
+		return l
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[k] = normalize(e)
+		}
+		return m
+	}
+	return v
+}
+
+// zeroValue returns the SDK shaped zero value of typ.
+func zeroValue(typ tftypes.Type) interface{} {
+	switch typ.(type) {
+	case tftypes.List, tftypes.Object:
+		return []interface{}{}
+	case tftypes.Set:
+		return schema.NewSet(HashValue, nil)
+	case tftypes.Map:
+		return map[string]interface{}{}
+	}
+	switch {
+	case typ.Is(tftypes.String):
+		return ""
+	case typ.Is(tftypes.Number):
+		return 0
+	case typ.Is(tftypes.Bool):
+		return false
+	}
+	return nil
+}
+
+// sdkValue converts v to the shape the SDK reads values in.
+func sdkValue(v tftypes.Value) (interface{}, error) {
+	typ := v.Type()
+	if !v.IsKnown() || v.IsNull() {
+		return zeroValue(typ), nil
+	}
+
+	switch typ.(type) {
+	case tftypes.List, tftypes.Set:
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		l := make([]interface{}, 0, len(elems))
+		for _, elem := range elems {
+			e, err := sdkValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, e)
+		}
+		if _, ok := typ.(tftypes.Set); ok {
+			return schema.NewSet(HashValue, l), nil
+		}
+		return l, nil
+	case tftypes.Map, tftypes.Object:
+		var elems map[string]tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		m := make(map[string]interface{}, len(elems))
+		for k, elem := range elems {
+			e, err := sdkValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = e
+		}
+		if _, ok := typ.(tftypes.Object); ok {
+			return []interface{}{m}, nil
+		}
+		return m, nil
+	}
+
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case typ.Is(tftypes.Number):
+		f := new(big.Float)
+		if err := v.As(&f); err != nil {
+			return nil, err
+		}
+		if i, acc := f.Int64(); acc == big.Exact {
+			return int(i), nil
+		}
+		f64, _ := f.Float64()
+		return f64, nil
+	}
+	return nil, fmt.Errorf("unsupported type %v", typ)
+}
+
+// tfValue converts v, in the shape returned by flatteners, to a value of typ.
+// planned is the value in the plan or state the resource data was read from,
+// or the zero value if there is none. If applied is set, planned is returned
+// when it is wholly known.
+func tfValue(typ tftypes.Type, v interface{}, planned tftypes.Value, applied bool) (tftypes.Value, error) {
+	if applied && planned.Type() != nil && planned.IsFullyKnown() {
+		return planned, nil
+	}
+	if set, ok := v.(*schema.Set); ok {
+		v = set.List()
+	}
+	if isZero(v) && isBlock(typ) {
+		// Terraform reads absent nested blocks as empty, rather than null, lists
+		return tftypes.NewValue(typ, []tftypes.Value{}), nil
+	}
+	if v == nil || (isZero(v) && planned.Type() != nil && planned.IsKnown() && planned.IsNull()) {
+		return tftypes.NewValue(typ, nil), nil
+	}
+
+	switch t := typ.(type) {
+	case tftypes.List, tftypes.Set:
+		elemType := elementType(t)
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice {
+			return tftypes.Value{}, fmt.Errorf("expected a list, found %T", v)
+		}
+		var plannedElems []tftypes.Value
+		if _, ok := t.(tftypes.List); ok && planned.Type() != nil && planned.IsKnown() && !planned.IsNull() {
+			if err := planned.As(&plannedElems); err != nil {
+				return tftypes.Value{}, err
+			}
+		}
+		elems := make([]tftypes.Value, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			var plannedElem tftypes.Value
+			if i < len(plannedElems) {
+				plannedElem = plannedElems[i]
+			}
+			elem, err := tfValue(elemType, rv.Index(i).Interface(), plannedElem, applied)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elems = append(elems, elem)
+		}
+		return tftypes.NewValue(typ, elems), nil
+	case tftypes.Map:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			return tftypes.Value{}, fmt.Errorf("expected a map, found %T", v)
+		}
+		plannedElems, err := plannedAttributes(planned)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		elems := make(map[string]tftypes.Value, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k := fmt.Sprintf("%v", iter.Key().Interface())
+			elem, err := tfValue(t.ElementType, iter.Value().Interface(), plannedElems[k], applied)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elems[k] = elem
+		}
+		return tftypes.NewValue(typ, elems), nil
+	case tftypes.Object:
+		if l, ok := v.([]interface{}); ok {
+			if len(l) == 0 || l[0] == nil {
+				return tftypes.NewValue(typ, nil), nil
+			}
+			v = l[0]
+		}
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected an object, found %T", v)
+		}
+		plannedAttrs, err := plannedAttributes(planned)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		attrs := make(map[string]tftypes.Value, len(t.AttributeTypes))
+		for name, attrType := range t.AttributeTypes {
+			attr, err := tfValue(attrType, m[name], plannedAttrs[name], applied)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", name, err)
+			}
+			attrs[name] = attr
+		}
+		return tftypes.NewValue(typ, attrs), nil
+	}
+
+	switch {
+	case typ.Is(tftypes.String):
+		if s, ok := v.(string); ok {
+			return tftypes.NewValue(typ, s), nil
+		}
+		return tftypes.NewValue(typ, fmt.Sprintf("%v", v)), nil
+	case typ.Is(tftypes.Bool):
+		b, ok := v.(bool)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected a bool, found %T", v)
+		}
+		return tftypes.NewValue(typ, b), nil
+	case typ.Is(tftypes.Number):
+		f, err := bigFloat(v)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		return tftypes.NewValue(typ, f), nil
+	}
+	return tftypes.Value{}, fmt.Errorf("unsupported type %v", typ)
+}
+
+// isBlock reports whether typ is a list or set of objects, the type of list
+// and set nested blocks.
+func isBlock(typ tftypes.Type) bool {
+	switch typ.(type) {
+	case tftypes.List, tftypes.Set:
+		_, ok := elementType(typ).(tftypes.Object)
+		return ok
+	}
+	return false
+}
+
+func elementType(typ tftypes.Type) tftypes.Type {
+	if s, ok := typ.(tftypes.Set); ok {
+		return s.ElementType
+	}
+	return typ.(tftypes.List).ElementType
+}
+
+func plannedAttributes(planned tftypes.Value) (map[string]tftypes.Value, error) {
+	var attrs map[string]tftypes.Value
+	if planned.Type() == nil || !planned.IsKnown() || planned.IsNull() {
+		return attrs, nil
+	}
+	err := planned.As(&attrs)
+	return attrs, err
+}
+
+func bigFloat(v interface{}) (*big.Float, error) {
+	switch n := v.(type) {
+	case int:
+		return new(big.Float).SetInt64(int64(n)), nil
+	case int32:
+		return new(big.Float).SetInt64(int64(n)), nil
+	case int64:
+		return new(big.Float).SetInt64(n), nil
+	case float32:
+		return big.NewFloat(float64(n)), nil
+	case float64:
+		return big.NewFloat(n), nil
+	case string:
+		f, _, err := big.ParseFloat(n, 10, 64, big.ToNearestEven)
+		return f, err
+	}
+	return nil, fmt.Errorf("expected a number, found %T", v)
+}
@@ -0,0 +1,193 @@
+package fwresource
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var testResourceDataType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"id":          tftypes.String,
+		"name":        tftypes.String,
+		"description": tftypes.String,
+		"size":        tftypes.Number,
+		"enabled":     tftypes.Bool,
+		"tags":        tftypes.Set{ElementType: tftypes.String},
+		"labels":      tftypes.Map{ElementType: tftypes.String},
+		"config": tftypes.Object{
+			AttributeTypes: map[string]tftypes.Type{
+				"mode": tftypes.String,
+			},
+		},
+		"rules": tftypes.List{
+			ElementType: tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"action": tftypes.String,
+				},
+			},
+		},
+	},
+}
+
+func testResourceDataValue(name string, size int64, config tftypes.Value) tftypes.Value {
+	return tftypes.NewValue(testResourceDataType, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name":        tftypes.NewValue(tftypes.String, name),
+		"description": tftypes.NewValue(tftypes.String, nil),
+		"size":        tftypes.NewValue(tftypes.Number, big.NewFloat(float64(size))),
+		"enabled":     tftypes.NewValue(tftypes.Bool, false),
+		"tags": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "a"),
+			tftypes.NewValue(tftypes.String, "b"),
+		}),
+		"labels": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+		"config": config,
+		"rules":  tftypes.NewValue(testResourceDataType.AttributeTypes["rules"], nil),
+	})
+}
+
+func testConfigValue(mode string) tftypes.Value {
+	configType := testResourceDataType.AttributeTypes["config"]
+	return tftypes.NewValue(configType, map[string]tftypes.Value{
+		"mode": tftypes.NewValue(tftypes.String, mode),
+	})
+}
+
+func TestResourceDataGet(t *testing.T) {
+	d, diags := NewResourceData(testResourceDataValue("example", 3, testConfigValue("FAST")), tftypes.Value{})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("name"); got != "example" {
+		t.Errorf("Get(name) = %v, expected example", got)
+	}
+	if got := d.Get("size"); got != 3 {
+		t.Errorf("Get(size) = %#v, expected 3", got)
+	}
+	if got := d.Get("config.0.mode"); got != "FAST" {
+		t.Errorf("Get(config.0.mode) = %v, expected FAST", got)
+	}
+	if got := d.Get("tags").(*schema.Set).Len(); got != 2 {
+		t.Errorf("Get(tags) has %d elements, expected 2", got)
+	}
+	if got := d.Get("description"); got != "" {
+		t.Errorf("Get(description) = %#v, expected an empty string", got)
+	}
+	if _, ok := d.GetOk("description"); ok {
+		t.Errorf("GetOk(description) is set, expected it to be unset")
+	}
+	if _, ok := d.GetOk("enabled"); ok {
+		t.Errorf("GetOk(enabled) is set, expected it to be unset")
+	}
+	if _, ok := d.GetOkExists("enabled"); !ok {
+		t.Errorf("GetOkExists(enabled) is unset, expected it to be set")
+	}
+	if d.Id() != "" {
+		t.Errorf("Id() = %q, expected an unknown id to be empty", d.Id())
+	}
+}
+
+func TestResourceDataHasChange(t *testing.T) {
+	prior := testResourceDataValue("example", 3, testConfigValue("FAST"))
+	d, diags := NewResourceData(testResourceDataValue("example", 4, testConfigValue("FAST")), prior)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	for key, expected := range map[string]bool{
+		"name":          false,
+		"size":          true,
+		"tags":          false,
+		"config":        false,
+		"config.0.mode": false,
+	} {
+		if got := d.HasChange(key); got != expected {
+			t.Errorf("HasChange(%s) = %t, expected %t", key, got, expected)
+		}
+	}
+}
+
+func TestResourceDataState(t *testing.T) {
+	configType := testResourceDataType.AttributeTypes["config"]
+	d, diags := NewResourceData(testResourceDataValue("example", 3, tftypes.NewValue(configType, nil)), tftypes.Value{})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	d.SetId("projects/p/widgets/example")
+	if err := d.Set("size", 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Set("description", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Set("config", []interface{}{map[string]interface{}{"mode": "SLOW"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Set("unknown", "value"); err == nil {
+		t.Errorf("Set(unknown) succeeded, expected an error")
+	}
+
+	state, diags := d.State()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	var attrs map[string]tftypes.Value
+	if err := state.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+
+	if !attrs["id"].Equal(tftypes.NewValue(tftypes.String, "projects/p/widgets/example")) {
+		t.Errorf("id = %v, expected the set id", attrs["id"])
+	}
+	if !attrs["size"].Equal(tftypes.NewValue(tftypes.Number, big.NewFloat(5))) {
+		t.Errorf("size = %v, expected 5", attrs["size"])
+	}
+	if !attrs["description"].IsNull() {
+		t.Errorf("description = %v, expected an empty value to stay null", attrs["description"])
+	}
+	if !attrs["labels"].IsNull() {
+		t.Errorf("labels = %v, expected an unset map to stay null", attrs["labels"])
+	}
+	if !attrs["rules"].Equal(tftypes.NewValue(testResourceDataType.AttributeTypes["rules"], []tftypes.Value{})) {
+		t.Errorf("rules = %v, expected an unset block to be an empty list", attrs["rules"])
+	}
+	if !attrs["config"].Equal(testConfigValue("SLOW")) {
+		t.Errorf("config = %v, expected the set object", attrs["config"])
+	}
+	if !state.IsFullyKnown() {
+		t.Errorf("State() = %v, expected it to be fully known", state)
+	}
+}
+
+func TestResourceDataAppliedState(t *testing.T) {
+	d, diags := NewResourceData(testResourceDataValue("example", 3, testConfigValue("FAST")), tftypes.Value{})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// Values read back from the API replace unknown values, but not known ones
+	d.SetId("projects/p/widgets/example")
+	if err := d.Set("name", "projects/p/widgets/example"); err != nil {
+		t.Fatal(err)
+	}
+
+	state, diags := d.AppliedState()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	var attrs map[string]tftypes.Value
+	if err := state.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	if !attrs["id"].Equal(tftypes.NewValue(tftypes.String, "projects/p/widgets/example")) {
+		t.Errorf("id = %v, expected the set id", attrs["id"])
+	}
+	if !attrs["name"].Equal(tftypes.NewValue(tftypes.String, "example")) {
+		t.Errorf("name = %v, expected the planned name", attrs["name"])
+	}
+}
@@ -9,9 +9,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	googleoauth "golang.org/x/oauth2/google"
 )
@@ -342,3 +345,63 @@ func (v TopicPrefixValidator) ValidateString(ctx context.Context, req validator.
 func NewTopicPrefixValidator() validator.String {
 	return TopicPrefixValidator{}
 }
+
+// SDK validation function Validator
+var (
+	_ validator.String  = SchemaValidateFuncValidator{}
+	_ validator.Int64   = SchemaValidateFuncValidator{}
+	_ validator.Float64 = SchemaValidateFuncValidator{}
+)
+
+// SchemaValidateFuncValidator validates an Attribute with a terraform-plugin-sdk
+// validation function, such as those used by generated SDK resources.
+type SchemaValidateFuncValidator struct {
+	ValidateFunc schema.SchemaValidateFunc
+}
+
+func (v SchemaValidateFuncValidator) Description(_ context.Context) string {
+	return "value must pass the resource's validation function"
+}
+
+func (v SchemaValidateFuncValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v SchemaValidateFuncValidator) validate(value interface{}, p path.Path, diags *diag.Diagnostics) {
+	warnings, errs := v.ValidateFunc(value, p.String())
+	for _, w := range warnings {
+		diags.AddAttributeWarning(p, "Attribute Validation Warning", w)
+	}
+	for _, err := range errs {
+		diags.AddAttributeError(p, "Invalid Attribute Value", err.Error())
+	}
+}
+
+func (v SchemaValidateFuncValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	v.validate(req.ConfigValue.ValueString(), req.Path, &resp.Diagnostics)
+}
+
+func (v SchemaValidateFuncValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	// SDK validation functions receive integers as an int
+	v.validate(int(req.ConfigValue.ValueInt64()), req.Path, &resp.Diagnostics)
+}
+
+func (v SchemaValidateFuncValidator) ValidateFloat64(ctx context.Context, req validator.Float64Request, resp *validator.Float64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	v.validate(req.ConfigValue.ValueFloat64(), req.Path, &resp.Diagnostics)
+}
+
+// SchemaValidateFunc returns a Validator that validates an Attribute with f.
+func SchemaValidateFunc(f schema.SchemaValidateFunc) SchemaValidateFuncValidator {
+	return SchemaValidateFuncValidator{
+		ValidateFunc: f,
+	}
+}
@@ -9,9 +9,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/hashicorp/terraform-provider-google/google/acctest"
 	"github.com/hashicorp/terraform-provider-google/google/fwvalidators"
+	"github.com/hashicorp/terraform-provider-google/google/verify"
 
 	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
 )
@@ -445,3 +447,53 @@ func TestTopicPrefixValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaValidateFuncValidator(t *testing.T) {
+	t.Parallel()
+
+	v := fwvalidators.SchemaValidateFunc(verify.ValidateEnum([]string{"FAST", "SLOW", ""}))
+
+	cases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"valid value": {
+			value: types.StringValue("FAST"),
+		},
+		"invalid value": {
+			value:       types.StringValue("MEDIUM"),
+			expectError: true,
+		},
+		"null value": {
+			value: types.StringNull(),
+		},
+	}
+
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("mode"),
+				ConfigValue: tc.value,
+			}
+			resp := &validator.StringResponse{}
+			v.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Errorf("expected error %t, got errors: %v", tc.expectError, resp.Diagnostics.Errors())
+			}
+		})
+	}
+
+	intValidator := fwvalidators.SchemaValidateFunc(validation.IntBetween(1, 10))
+	resp := &validator.Int64Response{}
+	intValidator.ValidateInt64(context.Background(), validator.Int64Request{
+		Path:        path.Root("size"),
+		ConfigValue: types.Int64Value(11),
+	}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected an error for an integer out of range")
+	}
+}
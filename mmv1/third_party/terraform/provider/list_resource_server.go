@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-google/google/registry"
+)
+
+// NewListResourceServer wraps a provider server, serving the list resources in the
+// registry for `terraform query`. meta returns the configured provider meta, which
+// resources are listed with.
+//
+// The SDK and the mux server don't serve list resources, so they're served here
+// from the SDK schemas of the listed resources.
+func NewListResourceServer(server tfprotov5.ProviderServer, meta func() interface{}) tfprotov5.ProviderServer {
+	return listResourceServer{
+		ProviderServer: server,
+		meta:           meta,
+	}
+}
+
+// nolint:staticcheck
+var _ tfprotov5.ProviderServerWithListResource = listResourceServer{}
+
+type listResourceServer struct {
+	tfprotov5.ProviderServer
+	meta func() interface{}
+}
+
+func (s listResourceServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	resp, err := s.ProviderServer.GetMetadata(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range registry.ListResources() {
+		resp.ListResources = append(resp.ListResources, tfprotov5.ListResourceMetadata{
+			TypeName: r.Name,
+		})
+	}
+	return resp, nil
+}
+
+func (s listResourceServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.ListResourceSchemas == nil {
+		resp.ListResourceSchemas = make(map[string]*tfprotov5.Schema)
+	}
+	for _, r := range registry.ListResources() {
+		resp.ListResourceSchemas[r.Name] = listResourceConfigSchema(r)
+	}
+	return resp, nil
+}
+
+func (s listResourceServer) ValidateListResourceConfig(ctx context.Context, req *tfprotov5.ValidateListResourceConfigRequest) (*tfprotov5.ValidateListResourceConfigResponse, error) {
+	r, ok := registry.GetListResource(req.TypeName)
+	if !ok {
+		return &tfprotov5.ValidateListResourceConfigResponse{
+			Diagnostics: []*tfprotov5.Diagnostic{unknownListResourceDiagnostic(req.TypeName)},
+		}, nil
+	}
+	// Unknown arguments are allowed during validation, as they're known by the time
+	// the resources are listed.
+	if _, err := listResourceArguments(r, req.Config, true); err != nil {
+		return &tfprotov5.ValidateListResourceConfigResponse{
+			Diagnostics: []*tfprotov5.Diagnostic{listResourceErrorDiagnostic(r, err)},
+		}, nil
+	}
+	return &tfprotov5.ValidateListResourceConfigResponse{}, nil
+}
+
+func (s listResourceServer) ListResource(ctx context.Context, req *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error) {
+	r, ok := registry.GetListResource(req.TypeName)
+	if !ok {
+		return listResourceErrorStream(unknownListResourceDiagnostic(req.TypeName)), nil
+	}
+	args, err := listResourceArguments(r, req.Config, false)
+	if err != nil {
+		return listResourceErrorStream(listResourceErrorDiagnostic(r, err)), nil
+	}
+	meta := s.meta()
+	if meta == nil {
+		return listResourceErrorStream(listResourceErrorDiagnostic(r, fmt.Errorf("the provider hasn't been configured"))), nil
+	}
+
+	results := func(yield func(tfprotov5.ListResourceResult) bool) {
+		var count int64
+		stopped := false
+		err := r.List(args, meta, func(d *schema.ResourceData) bool {
+			count++
+			if !yield(listResourceResult(r, d, req.IncludeResource)) {
+				stopped = true
+				return false
+			}
+			return req.Limit <= 0 || count < req.Limit
+		})
+		if err != nil && !stopped {
+			yield(tfprotov5.ListResourceResult{
+				Diagnostics: []*tfprotov5.Diagnostic{listResourceErrorDiagnostic(r, err)},
+			})
+		}
+	}
+	return &tfprotov5.ListResourceServerStream{Results: results}, nil
+}
+
+// listResourceConfigSchema returns the schema of a list block for a list resource.
+func listResourceConfigSchema(r registry.ListResource) *tfprotov5.Schema {
+	names := make([]string, 0, len(r.Arguments))
+	for name := range r.Arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	block := &tfprotov5.SchemaBlock{}
+	for _, name := range names {
+		arg := r.Arguments[name]
+		block.Attributes = append(block.Attributes, &tfprotov5.SchemaAttribute{
+			Name:        name,
+			Type:        tftypes.String,
+			Description: arg.Description,
+			Required:    arg.Required,
+			Optional:    !arg.Required,
+		})
+	}
+	return &tfprotov5.Schema{Block: block}
+}
+
+// listResourceArguments decodes the arguments of a list block. Null arguments are left
+// out, as are unknown ones if allowUnknown is set.
+func listResourceArguments(r registry.ListResource, config *tfprotov5.DynamicValue, allowUnknown bool) (map[string]string, error) {
+	args := make(map[string]string)
+	if config == nil {
+		return args, nil
+	}
+
+	attributeTypes := make(map[string]tftypes.Type, len(r.Arguments))
+	for name := range r.Arguments {
+		attributeTypes[name] = tftypes.String
+	}
+	objectType := tftypes.Object{AttributeTypes: attributeTypes}
+	val, err := config.Unmarshal(objectType)
+	if err != nil {
+		return nil, fmt.Errorf("decoding list block: %w", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := val.As(&attributes); err != nil {
+		return nil, fmt.Errorf("decoding list block: %w", err)
+	}
+	for name, v := range attributes {
+		if !v.IsKnown() {
+			if allowUnknown {
+				continue
+			}
+			return nil, fmt.Errorf("argument %q must be known when resources are listed", name)
+		}
+		if v.IsNull() {
+			continue
+		}
+		var s string
+		if err := v.As(&s); err != nil {
+			return nil, fmt.Errorf("decoding argument %q: %w", name, err)
+		}
+		args[name] = s
+	}
+	return args, nil
+}
+
+// listResourceResult converts a listed resource into a list result, including its
+// state if includeResource is set.
+func listResourceResult(r registry.ListResource, d *schema.ResourceData, includeResource bool) tfprotov5.ListResourceResult {
+	result := tfprotov5.ListResourceResult{
+		DisplayName: d.Id(),
+	}
+
+	identity, err := d.TfTypeIdentityState()
+	if err != nil {
+		result.Diagnostics = append(result.Diagnostics, listResourceErrorDiagnostic(r, err))
+		return result
+	}
+	identityData, err := tfprotov5.NewDynamicValue(identity.Type(), *identity)
+	if err != nil {
+		result.Diagnostics = append(result.Diagnostics, listResourceErrorDiagnostic(r, err))
+		return result
+	}
+	result.Identity = &tfprotov5.ResourceIdentityData{
+		IdentityData: &identityData,
+	}
+
+	if includeResource {
+		state, err := d.TfTypeResourceState()
+		if err != nil {
+			result.Diagnostics = append(result.Diagnostics, listResourceErrorDiagnostic(r, err))
+			return result
+		}
+		resource, err := tfprotov5.NewDynamicValue(state.Type(), *state)
+		if err != nil {
+			result.Diagnostics = append(result.Diagnostics, listResourceErrorDiagnostic(r, err))
+			return result
+		}
+		result.Resource = &resource
+	}
+	return result
+}
+
+func listResourceErrorStream(diagnostic *tfprotov5.Diagnostic) *tfprotov5.ListResourceServerStream {
+	return &tfprotov5.ListResourceServerStream{
+		Results: func(yield func(tfprotov5.ListResourceResult) bool) {
+			yield(tfprotov5.ListResourceResult{
+				Diagnostics: []*tfprotov5.Diagnostic{diagnostic},
+			})
+		},
+	}
+}
+
+func unknownListResourceDiagnostic(typeName string) *tfprotov5.Diagnostic {
+	return &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  "Unknown List Resource Type",
+		Detail:   fmt.Sprintf("The %q list resource type is not supported by this provider.", typeName),
+	}
+}
+
+func listResourceErrorDiagnostic(r registry.ListResource, err error) *tfprotov5.Diagnostic {
+	return &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  fmt.Sprintf("Error listing %s", r.Name),
+		Detail:   err.Error(),
+	}
+}
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-google/google/registry"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+var listTestWidgetResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"location": {Type: schema.TypeString, Required: true},
+		"name":     {Type: schema.TypeString, Required: true},
+	},
+	Identity: &schema.ResourceIdentity{
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"location": {Type: schema.TypeString, RequiredForImport: true},
+				"name":     {Type: schema.TypeString, RequiredForImport: true},
+			}
+		},
+	},
+}
+
+var listTestWidgetArgumentsType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"location": tftypes.String,
+		"filter":   tftypes.String,
+	},
+}
+
+func init() {
+	registry.ListResource{
+		Name:        "google_list_test_widget",
+		ProductName: "listtest",
+		Arguments: map[string]*schema.Schema{
+			"location": {Type: schema.TypeString, Required: true},
+			"filter":   {Type: schema.TypeString, Optional: true},
+		},
+		List: func(args map[string]string, meta interface{}, yield func(*schema.ResourceData) bool) error {
+			for _, name := range []string{"widget-a", "widget-b", "widget-c"} {
+				d := listTestWidgetResource.Data(nil)
+				if err := d.Set("location", args["location"]); err != nil {
+					return err
+				}
+				if err := d.Set("name", name); err != nil {
+					return err
+				}
+				d.SetId(fmt.Sprintf("locations/%s/widgets/%s", args["location"], name))
+
+				identity, err := d.Identity()
+				if err != nil {
+					return err
+				}
+				if err := identity.Set("location", args["location"]); err != nil {
+					return err
+				}
+				if err := identity.Set("name", name); err != nil {
+					return err
+				}
+				if !yield(d) {
+					return nil
+				}
+			}
+			return nil
+		},
+	}.Register()
+}
+
+func listTestWidgetConfig(t *testing.T, location interface{}) *tfprotov5.DynamicValue {
+	config, err := tfprotov5.NewDynamicValue(listTestWidgetArgumentsType, tftypes.NewValue(listTestWidgetArgumentsType, map[string]tftypes.Value{
+		"location": tftypes.NewValue(tftypes.String, location),
+		"filter":   tftypes.NewValue(tftypes.String, nil),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return &config
+}
+
+func TestListResourceServer_ListResource(t *testing.T) {
+	t.Parallel()
+
+	// nolint:staticcheck
+	server := NewListResourceServer(nil, func() interface{} {
+		return &transport_tpg.Config{}
+	}).(tfprotov5.ProviderServerWithListResource)
+
+	stream, err := server.ListResource(context.Background(), &tfprotov5.ListResourceRequest{
+		TypeName:        "google_list_test_widget",
+		Config:          listTestWidgetConfig(t, "us-central1"),
+		IncludeResource: true,
+		Limit:           2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var names []string
+	for result := range stream.Results {
+		for _, diagnostic := range result.Diagnostics {
+			t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+		if result.Resource == nil {
+			t.Errorf("expected the resource state of %q to be included", result.DisplayName)
+		}
+
+		identity, err := result.Identity.IdentityData.Unmarshal(tftypes.Object{
+			AttributeTypes: map[string]tftypes.Type{
+				"location": tftypes.String,
+				"name":     tftypes.String,
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error decoding the identity: %s", err)
+		}
+		var attributes map[string]tftypes.Value
+		if err := identity.As(&attributes); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var name string
+		if err := attributes["name"].As(&name); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		names = append(names, name)
+	}
+
+	if want := []string{"widget-a", "widget-b"}; !slices.Equal(names, want) {
+		t.Errorf("Expected listed identities %v, got %v", want, names)
+	}
+}
+
+func TestListResourceServer_ListResourceErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		TypeName        string
+		Config          *tfprotov5.DynamicValue
+		Meta            interface{}
+		ExpectedSummary string
+	}{
+		"unknown list resource": {
+			TypeName:        "google_list_test_gadget",
+			Config:          listTestWidgetConfig(t, "us-central1"),
+			Meta:            &transport_tpg.Config{},
+			ExpectedSummary: "Unknown List Resource Type",
+		},
+		"unknown argument": {
+			TypeName:        "google_list_test_widget",
+			Config:          listTestWidgetConfig(t, tftypes.UnknownValue),
+			Meta:            &transport_tpg.Config{},
+			ExpectedSummary: "Error listing google_list_test_widget",
+		},
+		"unconfigured provider": {
+			TypeName:        "google_list_test_widget",
+			Config:          listTestWidgetConfig(t, "us-central1"),
+			ExpectedSummary: "Error listing google_list_test_widget",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			// nolint:staticcheck
+			server := NewListResourceServer(nil, func() interface{} {
+				return tc.Meta
+			}).(tfprotov5.ProviderServerWithListResource)
+
+			stream, err := server.ListResource(context.Background(), &tfprotov5.ListResourceRequest{
+				TypeName: tc.TypeName,
+				Config:   tc.Config,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var summaries []string
+			for result := range stream.Results {
+				for _, diagnostic := range result.Diagnostics {
+					summaries = append(summaries, diagnostic.Summary)
+				}
+			}
+			if want := []string{tc.ExpectedSummary}; !slices.Equal(summaries, want) {
+				t.Errorf("Expected diagnostics %v, got %v", want, summaries)
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package registry
 
 import (
 	"log"
+	"sort"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -117,3 +120,153 @@ func DataSource(name string) *schema.Resource {
 	}
 	return d.Schema
 }
+
+// FrameworkResource is used to configure a plugin framework resource within the registry.
+type FrameworkResource struct {
+	// Name is the externally visible name, e.g., "google_alloydb_cluster".
+	Name string
+	// ProductName is the `Product` that this `FrameworkResource` is associated with.
+	ProductName string
+	// Resource returns a new instance of the resource.
+	Resource func() resource.Resource
+}
+
+// Register adds the plugin framework resource to the internal registry.
+func (r FrameworkResource) Register() {
+	schemas.Lock()
+	defer schemas.Unlock()
+	if _, ok := schemas.r[r.Name]; ok {
+		log.Fatalf("Duplicate registration attempt for resource %q", r.Name)
+	}
+	if _, ok := frameworkResources.m[r.Name]; ok {
+		log.Fatalf("Duplicate registration attempt for resource %q", r.Name)
+	}
+	frameworkResources.m[r.Name] = r
+}
+
+type registeredFrameworkResources struct {
+	m map[string]FrameworkResource
+}
+
+var frameworkResources = &registeredFrameworkResources{
+	m: make(map[string]FrameworkResource),
+}
+
+// FrameworkResources returns the registered plugin framework resources, sorted by name.
+func FrameworkResources() []func() resource.Resource {
+	schemas.RLock()
+	defer schemas.RUnlock()
+	names := make([]string, 0, len(frameworkResources.m))
+	for name := range frameworkResources.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	resources := make([]func() resource.Resource, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, frameworkResources.m[name].Resource)
+	}
+	return resources
+}
+
+// EphemeralResource is used to configure a plugin framework ephemeral resource within the registry.
+type EphemeralResource struct {
+	// Name is the externally visible name, e.g., "google_service_account_access_token".
+	Name string
+	// ProductName is the `Product` that this `EphemeralResource` is associated with.
+	ProductName string
+	// EphemeralResource returns a new instance of the ephemeral resource.
+	EphemeralResource func() ephemeral.EphemeralResource
+}
+
+// Register adds the ephemeral resource to the internal registry.
+func (r EphemeralResource) Register() {
+	schemas.Lock()
+	defer schemas.Unlock()
+	if _, ok := ephemeralResources.m[r.Name]; ok {
+		log.Fatalf("Duplicate registration attempt for ephemeral resource %q", r.Name)
+	}
+	ephemeralResources.m[r.Name] = r
+}
+
+type registeredEphemeralResources struct {
+	m map[string]EphemeralResource
+}
+
+var ephemeralResources = &registeredEphemeralResources{
+	m: make(map[string]EphemeralResource),
+}
+
+// EphemeralResources returns the registered ephemeral resources, sorted by name.
+func EphemeralResources() []func() ephemeral.EphemeralResource {
+	schemas.RLock()
+	defer schemas.RUnlock()
+	names := make([]string, 0, len(ephemeralResources.m))
+	for name := range ephemeralResources.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	resources := make([]func() ephemeral.EphemeralResource, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, ephemeralResources.m[name].EphemeralResource)
+	}
+	return resources
+}
+
+// ListResource is used to configure a list resource within the registry. List resources
+// enumerate the existing instances of a resource for `terraform query`.
+type ListResource struct {
+	// Name is the externally visible name of the listed resource, e.g., "google_alloydb_cluster".
+	Name string
+	// ProductName is the `Product` that this `ListResource` is associated with.
+	ProductName string
+	// Arguments contains the string arguments of a list block for this resource.
+	Arguments map[string]*schema.Schema
+	// List reads the resources matching the arguments into data for the listed resource's
+	// schema, calling yield with each one until it returns false.
+	List ListFunc
+}
+
+// ListFunc lists the resources matching args, calling yield with each one.
+type ListFunc func(args map[string]string, meta interface{}, yield func(*schema.ResourceData) bool) error
+
+// Register adds the list resource to the internal registry.
+func (r ListResource) Register() {
+	schemas.Lock()
+	defer schemas.Unlock()
+	if _, ok := listResources.m[r.Name]; ok {
+		log.Fatalf("Duplicate registration attempt for list resource %q", r.Name)
+	}
+	listResources.m[r.Name] = r
+}
+
+type registeredListResources struct {
+	m map[string]ListResource
+}
+
+var listResources = &registeredListResources{
+	m: make(map[string]ListResource),
+}
+
+// ListResources returns the registered list resources, sorted by name.
+func ListResources() []ListResource {
+	schemas.RLock()
+	defer schemas.RUnlock()
+	names := make([]string, 0, len(listResources.m))
+	for name := range listResources.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	resources := make([]ListResource, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, listResources.m[name])
+	}
+	return resources
+}
+
+// GetListResource returns the list resource with the given name, if it is registered.
+func GetListResource(name string) (ListResource, bool) {
+	schemas.RLock()
+	defer schemas.RUnlock()
+	r, ok := listResources.m[name]
+	return r, ok
+}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-google/google/acctest"
 	"github.com/hashicorp/terraform-provider-google/google/envvar"
+	"github.com/hashicorp/terraform-provider-google/google/registry"
 )
 
 func TestAccPubsubTopic_update(t *testing.T) {
@@ -43,6 +46,66 @@ func TestAccPubsubTopic_update(t *testing.T) {
 	})
 }
 
+func TestAccPubsubTopic_list(t *testing.T) {
+	t.Parallel()
+
+	topic := fmt.Sprintf("tf-test-topic-%s", acctest.RandString(t, 10))
+
+	acctest.VcrTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		CheckDestroy:             testAccCheckPubsubTopicDestroyProducer(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPubsubTopic_update(topic, "foo", "bar"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPubsubTopicListed(t, "google_pubsub_topic.foo"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckPubsubTopicListed checks that the google_pubsub_topic list
+// resource finds the topic n, with the same id and fields as its state.
+func testAccCheckPubsubTopicListed(t *testing.T, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+		lr, ok := registry.GetListResource("google_pubsub_topic")
+		if !ok {
+			return fmt.Errorf("List resource google_pubsub_topic is not registered")
+		}
+
+		var listed *schema.ResourceData
+		args := map[string]string{"project": rs.Primary.Attributes["project"]}
+		err := lr.List(args, acctest.GoogleProviderConfig(t), func(d *schema.ResourceData) bool {
+			if d.Id() == rs.Primary.ID {
+				listed = d
+				return false
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		if listed == nil {
+			return fmt.Errorf("Topic %s was not listed", rs.Primary.ID)
+		}
+		for _, k := range []string{"name", "project"} {
+			if got, want := listed.Get(k).(string), rs.Primary.Attributes[k]; got != want {
+				return fmt.Errorf("Listed topic %s is %q, expected %q", k, got, want)
+			}
+		}
+		if got := listed.Get("effective_labels").(map[string]interface{})["foo"]; got != "bar" {
+			return fmt.Errorf("Listed topic label foo is %v, expected %q", got, "bar")
+		}
+		return nil
+	}
+}
+
 func TestAccPubsubTopic_cmek(t *testing.T) {
 	t.Parallel()
 
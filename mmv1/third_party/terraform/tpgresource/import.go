@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
 	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
 )
 
@@ -75,6 +77,68 @@ func ParseImportId(idRegexes []string, d TerraformResourceData, config *transpor
 	return fmt.Errorf("Import id %q doesn't match any of the accepted formats: %v", d.Id(), idRegexes)
 }
 
+// Set the identity attributes of a resource to the values of the fields with
+// the same names, as they're substituted into its id. shorten is set for ids
+// built with ReplaceVarsForId.
+func SetIdentity(fieldNames []string, d *schema.ResourceData, config *transport_tpg.Config, shorten bool) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return err
+	}
+	for _, fieldName := range fieldNames {
+		fieldValue, err := ReplaceVarsRecursive(d, config, fmt.Sprintf("{{%s}}", fieldName), shorten, 0)
+		if err != nil {
+			return err
+		}
+		if err := identity.Set(fieldName, fieldValue); err != nil {
+			return fmt.Errorf("Error setting identity %s: %s", fieldName, err)
+		}
+	}
+	return nil
+}
+
+// Build the import id of a resource imported by identity rather than by id,
+// replacing the fields in idFormat with the identity attributes. project,
+// region and zone use the provider-level defaults if they aren't set.
+//
+// e.g: projects/{{project}}/regions/{{region}}/subnetworks/{{name}}
+func ImportIdFromIdentity(idFormat string, d *schema.ResourceData, config *transport_tpg.Config) (string, error) {
+	identity, err := d.Identity()
+	if err != nil {
+		return "", err
+	}
+
+	re := regexp.MustCompile("{{([[:word:]]+)}}")
+	var errs []string
+	id := re.ReplaceAllStringFunc(idFormat, func(s string) string {
+		fieldName := re.FindStringSubmatch(s)[1]
+		if v, ok := identity.GetOk(fieldName); ok {
+			return fmt.Sprintf("%v", v)
+		}
+
+		var fieldValue string
+		var err error
+		switch fieldName {
+		case "project":
+			fieldValue, err = GetProject(d, config)
+		case "region":
+			fieldValue, err = GetRegion(d, config)
+		case "zone":
+			fieldValue, err = GetZone(d, config)
+		default:
+			err = fmt.Errorf("identity attribute %s is required", fieldName)
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		return fieldValue
+	})
+	if len(errs) > 0 {
+		return "", fmt.Errorf("Error importing by identity: %s", strings.Join(errs, ", "))
+	}
+	return id, nil
+}
+
 func setDefaultValues(idRegex string, d TerraformResourceData, config *transport_tpg.Config) error {
 	if _, ok := d.GetOk("project"); !ok && strings.Contains(idRegex, "?P<project>") {
 		project, err := GetProject(d, config)
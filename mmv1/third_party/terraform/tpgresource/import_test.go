@@ -3,6 +3,8 @@ package tpgresource
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
 	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
 )
 
@@ -147,3 +149,120 @@ func TestParseImportId(t *testing.T) {
 		}
 	}
 }
+
+var subnetworkIdentityResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"project": {Type: schema.TypeString, Optional: true},
+		"region":  {Type: schema.TypeString, Optional: true},
+		"name":    {Type: schema.TypeString, Required: true},
+	},
+	Identity: &schema.ResourceIdentity{
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"project": {Type: schema.TypeString, OptionalForImport: true},
+				"region":  {Type: schema.TypeString, OptionalForImport: true},
+				"name":    {Type: schema.TypeString, RequiredForImport: true},
+			}
+		},
+	},
+}
+
+func TestSetIdentity(t *testing.T) {
+	d := subnetworkIdentityResource.TestResourceData()
+	if err := d.Set("name", "my-subnetwork"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	config := &transport_tpg.Config{
+		Project: "default-project",
+		Region:  "default-region",
+	}
+	if err := SetIdentity([]string{"project", "region", "name"}, d, config, false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for k, expectedValue := range map[string]string{
+		"project": "default-project",
+		"region":  "default-region",
+		"name":    "my-subnetwork",
+	} {
+		if v := identity.Get(k); v != expectedValue {
+			t.Errorf("Expected identity value %q for %q, got %q", expectedValue, k, v)
+		}
+	}
+}
+
+func TestImportIdFromIdentity(t *testing.T) {
+	idFormat := "projects/{{project}}/regions/{{region}}/subnetworks/{{name}}"
+	cases := map[string]struct {
+		Identity    map[string]string
+		Config      *transport_tpg.Config
+		ExpectedId  string
+		ExpectError bool
+	}{
+		"all attributes": {
+			Identity: map[string]string{
+				"project": "my-project",
+				"region":  "my-region",
+				"name":    "my-subnetwork",
+			},
+			ExpectedId: "projects/my-project/regions/my-region/subnetworks/my-subnetwork",
+		},
+		"default project and region": {
+			Identity: map[string]string{
+				"name": "my-subnetwork",
+			},
+			Config: &transport_tpg.Config{
+				Project: "default-project",
+				Region:  "default-region",
+			},
+			ExpectedId: "projects/default-project/regions/default-region/subnetworks/my-subnetwork",
+		},
+		"provider-level defaults not set": {
+			Identity: map[string]string{
+				"name": "my-subnetwork",
+			},
+			ExpectError: true,
+		},
+		"required attribute not set": {
+			Identity: map[string]string{
+				"project": "my-project",
+				"region":  "my-region",
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := subnetworkIdentityResource.TestResourceData()
+		identity, err := d.Identity()
+		if err != nil {
+			t.Fatalf("%s failed; unexpected error: %s", tn, err)
+		}
+		for k, v := range tc.Identity {
+			if err := identity.Set(k, v); err != nil {
+				t.Fatalf("%s failed; unexpected error: %s", tn, err)
+			}
+		}
+		config := tc.Config
+		if config == nil {
+			config = &transport_tpg.Config{}
+		}
+
+		id, err := ImportIdFromIdentity(idFormat, d, config)
+		if err != nil {
+			if !tc.ExpectError {
+				t.Errorf("%s failed; unexpected error: %s", tn, err)
+			}
+			continue
+		}
+		if tc.ExpectError {
+			t.Errorf("%s failed; expected an error, got id %q", tn, id)
+		} else if id != tc.ExpectedId {
+			t.Errorf("%s failed; expected id %q, got %q", tn, tc.ExpectedId, id)
+		}
+	}
+}
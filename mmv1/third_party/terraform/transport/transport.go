@@ -144,7 +144,10 @@ func AddArrayQueryParams(rawurl string, param string, values []interface{}) (str
 	return u.String(), nil
 }
 
-func HandleNotFoundError(err error, d *schema.ResourceData, resource string) error {
+// HandleNotFoundError clears the id of d if err is a 404, so that the resource
+// is removed from state. d is usually a *schema.ResourceData, or the resource
+// data of a generated plugin framework resource.
+func HandleNotFoundError(err error, d interface{ SetId(string) }, resource string) error {
 	if IsGoogleApiErrorWithCode(err, 404) {
 		log.Printf("[WARN] Removing %s because it's gone", resource)
 		// The resource doesn't exist anymore